@@ -0,0 +1,154 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/moeryomenko/healing"
+	"github.com/moeryomenko/synx"
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+var (
+	// ErrAMQPClosed indicates that amqp connection has been closed.
+	ErrAMQPClosed = errors.New("amqp connection is closed")
+	// ErrAMQPBlocked indicates that broker has blocked amqp connection.
+	ErrAMQPBlocked = errors.New("amqp connection is blocked")
+	// ErrAMQPQueue indicates that queue crosses limits of depth or consumers.
+	ErrAMQPQueue = errors.New("amqp queue is not ready")
+)
+
+// AMQPProbes returns liveness and readiness probes for amqp connection. Liveness fails
+// after connection is closed, readiness fails while connection is blocked by broker too.
+// If queue is set by WithAMQPQueue, readiness checks its depth and consumers.
+func AMQPProbes(conn *amqp.Connection, opts ...AMQPOptions) (
+	liveness, readiness healing.CheckerFunc,
+) {
+	cfg := newAMQPConfig(opts...)
+
+	state := &amqpState{}
+	// NOTE: notifications are consumed by own goroutine, so no one is missed,
+	// goroutine exits after connection is closed.
+	go state.watch(
+		conn.NotifyClose(make(chan *amqp.Error, 1)),
+		conn.NotifyBlocked(make(chan amqp.Blocking, 1)),
+	)
+
+	return func(ctx context.Context) healing.CheckResult {
+			return CheckHelper(state.closedErr)
+		}, func(ctx context.Context) healing.CheckResult {
+			details := make(map[string]any)
+			res := CheckHelper(func() error {
+				if err := state.err(); err != nil {
+					return err
+				}
+				if cfg.queue == "" {
+					return nil
+				}
+				queue, err := inspectQueue(conn, cfg.queue)
+				if err != nil {
+					return err
+				}
+				return checkQueue(queue, cfg, details)
+			})
+			res.Details = details
+			return res
+		}
+}
+
+// amqpState holds state of amqp connection until it changes.
+type amqpState struct {
+	mu synx.Spinlock
+
+	closed  bool
+	reason  error
+	blocked string
+}
+
+// watch tracks notifications of connection until both channels are closed.
+func (s *amqpState) watch(closed <-chan *amqp.Error, blocked <-chan amqp.Blocking) {
+	for closed != nil || blocked != nil {
+		select {
+		case err, ok := <-closed:
+			s.mu.Lock()
+			s.closed = true
+			if ok && err != nil {
+				s.reason = err
+			}
+			s.mu.Unlock()
+			// NOTE: connection is closed only once, after that notification channels are closed.
+			if !ok {
+				closed = nil
+			}
+		case b, ok := <-blocked:
+			if !ok {
+				blocked = nil
+				continue
+			}
+			s.mu.Lock()
+			s.blocked = ""
+			if b.Active {
+				s.blocked = b.Reason
+			}
+			s.mu.Unlock()
+		}
+	}
+}
+
+func (s *amqpState) closedErr() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.closed {
+		return nil
+	}
+	if s.reason != nil {
+		return fmt.Errorf("%w: %w", ErrAMQPClosed, s.reason)
+	}
+	return ErrAMQPClosed
+}
+
+func (s *amqpState) err() error {
+	if err := s.closedErr(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.blocked != "" {
+		return fmt.Errorf("%w: %s", ErrAMQPBlocked, s.blocked)
+	}
+	return nil
+}
+
+// inspectQueue declares queue passively on own channel, so connection
+// isn't affected if queue doesn't exist.
+func inspectQueue(conn *amqp.Connection, name string) (amqp.Queue, error) {
+	ch, err := conn.Channel()
+	if err != nil {
+		return amqp.Queue{}, err
+	}
+	queue, err := ch.QueueDeclarePassive(name, false, false, false, false, nil)
+	if err != nil {
+		// NOTE: failed declaration closes channel by server.
+		return amqp.Queue{}, err
+	}
+	return queue, ch.Close()
+}
+
+// checkQueue checks depth and consumers of queue and fills details of check result.
+func checkQueue(queue amqp.Queue, cfg amqp_config, details map[string]any) error {
+	details["queue"] = queue.Name
+	details["messages"] = queue.Messages
+	details["consumers"] = queue.Consumers
+
+	switch {
+	case cfg.maxMessages > 0 && queue.Messages > cfg.maxMessages:
+		return fmt.Errorf("%w: %d messages > %d", ErrAMQPQueue, queue.Messages, cfg.maxMessages)
+	case queue.Consumers < cfg.minConsumers:
+		return fmt.Errorf("%w: %d consumers < %d", ErrAMQPQueue, queue.Consumers, cfg.minConsumers)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package checkers
+
+import (
+	"testing"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAMQPState(t *testing.T) {
+	closed := make(chan *amqp.Error, 1)
+	blocked := make(chan amqp.Blocking, 1)
+
+	state := &amqpState{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		state.watch(closed, blocked)
+	}()
+
+	assert.NoError(t, state.err())
+
+	// NOTE: connection stays blocked until broker unblocks it.
+	blocked <- amqp.Blocking{Active: true, Reason: "low on memory"}
+	assert.Eventually(t, func() bool { return state.err() != nil }, time.Second, time.Millisecond)
+	assert.ErrorIs(t, state.err(), ErrAMQPBlocked)
+	assert.NoError(t, state.closedErr())
+
+	blocked <- amqp.Blocking{Active: false}
+	assert.Eventually(t, func() bool { return state.err() == nil }, time.Second, time.Millisecond)
+
+	closed <- amqp.ErrClosed
+	close(closed)
+	close(blocked)
+	<-done
+
+	assert.ErrorIs(t, state.closedErr(), ErrAMQPClosed)
+	assert.ErrorIs(t, state.closedErr(), amqp.ErrClosed)
+	assert.ErrorIs(t, state.err(), ErrAMQPClosed)
+}
+
+func TestCheckQueue(t *testing.T) {
+	testcases := []struct {
+		name    string
+		queue   amqp.Queue
+		opts    []AMQPOptions
+		healthy bool
+	}{
+		{
+			name:    "unlimited queue",
+			queue:   amqp.Queue{Name: "jobs", Messages: 1000},
+			healthy: true,
+		},
+		{
+			name:    "queue within limits",
+			queue:   amqp.Queue{Name: "jobs", Messages: 10, Consumers: 2},
+			opts:    []AMQPOptions{WithMaxQueueMessages(100), WithMinQueueConsumers(1)},
+			healthy: true,
+		},
+		{
+			name:  "too deep queue",
+			queue: amqp.Queue{Name: "jobs", Messages: 101, Consumers: 2},
+			opts:  []AMQPOptions{WithMaxQueueMessages(100)},
+		},
+		{
+			name:  "no consumers",
+			queue: amqp.Queue{Name: "jobs"},
+			opts:  []AMQPOptions{WithMinQueueConsumers(1)},
+		},
+	}
+	for _, testcase := range testcases {
+		tc := testcase
+		t.Run(tc.name, func(t *testing.T) {
+			details := map[string]any{}
+			err := checkQueue(tc.queue, newAMQPConfig(tc.opts...), details)
+			if tc.healthy {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrAMQPQueue)
+			}
+			assert.Equal(t, tc.queue.Messages, details["messages"])
+			assert.Equal(t, tc.queue.Consumers, details["consumers"])
+		})
+	}
+}
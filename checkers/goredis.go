@@ -7,23 +7,47 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
+// RedisProbes returns liveness and readiness probes for go-redis redis.Client.
+func RedisProbes(client *redis.Client, opts ...PoolOptions) (
+	liveness, readiness healing.CheckerFunc,
+) {
+	return PoolLivenessProber(func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	}, opts...), RedisReadinessProber(client, opts...)
+}
+
 // RedisReadinessProber returns redis conn pool readiness checker function.
-func RedisReadinessProber(client *redis.Client, opts ...PoolOptions) func(context.Context) healing.CheckResult {
-	cfg := pool_config{lowerLimit: defaultLowerLimit}
+func RedisReadinessProber(client *redis.Client, opts ...PoolOptions) healing.CheckerFunc {
+	return PoolReadinessProber(func() PoolStats {
+		stats := client.PoolStats()
+		return PoolStats{
+			Idle:  int(stats.IdleConns),
+			InUse: max(int(stats.TotalConns)-int(stats.IdleConns), 0),
+			Total: int(stats.TotalConns),
+			Max:   client.Options().PoolSize,
+		}
+	}, func(ctx context.Context) error {
+		return client.Ping(ctx).Err()
+	}, opts...)
+}
 
-	for _, opt := range opts {
-		opt(&cfg)
-	}
+// RedisNodeProber returns readiness checker function of redis node, which checks
+// replication and persistence by INFO command. It fails if replica has lost link
+// with master, replicas fall behind master over limit, or last RDB save or AOF write
+// has failed. Checked fields are reported in details of check result.
+func RedisNodeProber(client redis.UniversalClient, opts ...RedisInfoOptions) healing.CheckerFunc {
+	cfg := newRedisInfoConfig(opts...)
 
 	return func(ctx context.Context) healing.CheckResult {
-		return CheckHelper(func() error {
-			stats := client.PoolStats()
-
-			return poolCheck(ctx,
-				int(stats.IdleConns), int(stats.TotalConns), cfg.lowerLimit,
-				func(ctx context.Context) error {
-					return client.Ping(ctx).Err()
-				})
+		details := make(map[string]any)
+		res := CheckHelper(func() error {
+			info, err := client.Info(ctx).Result()
+			if err != nil {
+				return err
+			}
+			return redisInfoCheck(info, cfg, details)
 		})
+		res.Details = details
+		return res
 	}
 }
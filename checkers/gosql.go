@@ -9,27 +9,13 @@ import (
 
 // SQLProbes returns liveness and readiness probes for go sql.DB.
 func SQLProbes(db *sql.DB, opts ...PoolOptions) (
-	liveness, readiness func(context.Context) healing.CheckResult,
+	liveness, readiness healing.CheckerFunc,
 ) {
-	cfg := pool_config{lowerLimit: defaultLowerLimit, livenessPeriod: defaultPoolLivenessPeriod}
-
-	for _, opt := range opts {
-		opt(&cfg)
-	}
-
-	return func(ctx context.Context) healing.CheckResult {
-		return CheckHelper(checkPoolLiveness(ctx, cfg.livenessPeriod, db.PingContext))
-	}, SQLPoolReadinessChecker(db, opts...)
+	return PoolLivenessProber(db.PingContext, opts...), SQLPoolReadinessChecker(db, opts...)
 }
 
 // SQLPoolReadinessChecker returns readiness checker function for golang sql.DB.
-func SQLPoolReadinessChecker(db *sql.DB, opts ...PoolOptions) func(context.Context) healing.CheckResult {
-	cfg := pool_config{lowerLimit: defaultLowerLimit}
-
-	for _, opt := range opts {
-		opt(&cfg)
-	}
-
+func SQLPoolReadinessChecker(db *sql.DB, opts ...PoolOptions) healing.CheckerFunc {
 	check := func(ctx context.Context) error {
 		conn, err := db.Conn(ctx)
 		if err != nil {
@@ -38,10 +24,14 @@ func SQLPoolReadinessChecker(db *sql.DB, opts ...PoolOptions) func(context.Conte
 		return conn.Close()
 	}
 
-	return func(ctx context.Context) healing.CheckResult {
-		return CheckHelper(func() error {
-			stats := db.Stats()
-			return poolCheck(ctx, stats.Idle, stats.MaxOpenConnections, cfg.lowerLimit, check)
-		})
-	}
+	return PoolReadinessProber(func() PoolStats {
+		stats := db.Stats()
+		return PoolStats{
+			Idle:    stats.Idle,
+			InUse:   stats.InUse,
+			Total:   stats.OpenConnections,
+			Max:     stats.MaxOpenConnections,
+			Acquire: &AcquireStats{Waits: stats.WaitCount, WaitTime: stats.WaitDuration},
+		}
+	}, check, opts...)
 }
@@ -3,6 +3,8 @@ package checkers
 import (
 	"context"
 	"errors"
+	"fmt"
+	"sync"
 	"time"
 
 	"github.com/moeryomenko/healing"
@@ -26,33 +28,188 @@ func CheckHelper(check func() error) healing.CheckResult {
 	}
 }
 
-func checkPoolLiveness(ctx context.Context, period time.Duration, ping func(context.Context) error) func() error {
-	// NOTE: liveness ping has owen check period, cause dont annoy db by ping command.
-	lastPing := time.Now()
-	return func() error {
-		now := time.Now()
-		if now.After(lastPing) {
-			lastPing = now
-			return ping(ctx)
-		}
+// PoolStats is snapshot of connection pool statistics.
+type PoolStats struct {
+	Idle, InUse, Total int
+	// Max is size of pool, zero means unlimited pool.
+	Max int
+	// Pending is number of acquires currently waiting for connection,
+	// zero if pool doesn't expose it.
+	Pending int64
+	// Acquire is cumulative statistics of waits for connection,
+	// nil if pool doesn't collect it.
+	Acquire *AcquireStats
+}
+
+// details returns connection pool statistics as details of check result.
+func (s PoolStats) details() map[string]any {
+	details := map[string]any{
+		"idle_conns":   s.Idle,
+		"in_use_conns": s.InUse,
+		"total_conns":  s.Total,
+		"max_conns":    s.Max,
+	}
+	if s.Pending > 0 {
+		details["pending_acquires"] = s.Pending
+	}
+	return details
+}
+
+// PoolProbes returns liveness and readiness probes for arbitrary connection pool.
+// For more details see PoolLivenessProber and PoolReadinessProber.
+func PoolProbes(ping func(context.Context) error, stats func() PoolStats, check func(context.Context) error, opts ...PoolOptions) (
+	liveness, readiness healing.CheckerFunc,
+) {
+	return PoolLivenessProber(ping, opts...), PoolReadinessProber(stats, check, opts...)
+}
+
+// PoolLivenessProber returns liveness checker function for arbitrary connection pool,
+// which checks pool by given ping. By default pool is pinged on every check, so frequency
+// of pings is set by interval of checker, see healing.WithCheckInterval. If liveness period
+// is set by WithPoolLivenessPeriod, result of ping is reused by checks until period passes.
+func PoolLivenessProber(ping func(context.Context) error, opts ...PoolOptions) healing.CheckerFunc {
+	cfg := newPoolConfig(opts...)
+
+	var (
+		mu       sync.Mutex
+		lastPing time.Time
+		lastErr  error
+	)
+	return func(ctx context.Context) healing.CheckResult {
+		return CheckHelper(func() error {
+			mu.Lock()
+			defer mu.Unlock()
+
+			// NOTE: liveness ping has own period, cause dont annoy db by ping command.
+			if now := time.Now(); lastPing.IsZero() || now.Sub(lastPing) >= cfg.livenessPeriod {
+				lastPing, lastErr = now, ping(ctx)
+			}
+			return lastErr
+		})
+	}
+}
+
+// PoolReadinessProber returns readiness checker function for arbitrary connection pool,
+// which checks saturation of pool by its statistics and then, unless passive check is enabled,
+// checks pool by given check, e.g. acquiring of connection.
+func PoolReadinessProber(stats func() PoolStats, check func(context.Context) error, opts ...PoolOptions) healing.CheckerFunc {
+	cfg := newPoolConfig(opts...)
+
+	var monitor *acquireMonitor
+	if initial := stats(); initial.Acquire != nil {
+		monitor = newAcquireMonitor(cfg, *initial.Acquire)
+	}
+
+	return func(ctx context.Context) healing.CheckResult {
+		stats := stats()
+		details := stats.details()
+
+		res := CheckHelper(func() error {
+			err := poolCheck(stats, cfg)
+			if monitor != nil && stats.Acquire != nil {
+				err = errors.Join(err, monitor.check(*stats.Acquire, details))
+			}
+			if err != nil || cfg.passive {
+				return err
+			}
+
+			return check(ctx)
+		})
+		res.Details = details
+		return res
+	}
+}
+
+// poolCheck checks saturation of pool. Free connections are connections, which are not in use,
+// including not opened yet, pool is saturated if number or percent of free connections
+// reaches lower limits, since there are not enough free connections
+// that will be useful for processing incoming requests. Pool is saturated too
+// if number of pending acquires exposed by pool exceeds limit, even if pool is unlimited.
+func poolCheck(stats PoolStats, cfg pool_config) error {
+	if err := pendingCheck(stats.Pending, cfg); err != nil {
+		return err
+	}
+
+	if stats.Max <= 0 {
+		// NOTE: size of pool is unlimited.
 		return nil
 	}
+
+	free := max(stats.Max-stats.InUse, 0)
+
+	if free < cfg.minFree {
+		return fmt.Errorf("%w: %d free connections, limit %d", ErrPoolNotReady, free, cfg.minFree)
+	}
+
+	if percentFree := free * 100 / stats.Max; percentFree <= cfg.lowerLimit {
+		return fmt.Errorf("%w: %d%% free connections, limit %d%%", ErrPoolNotReady, percentFree, cfg.lowerLimit)
+	}
+
+	return nil
 }
 
-func poolCheck(ctx context.Context, idle, max, lower int, check func(context.Context) error) error {
-	// NOTE: if ratio idle connection in pull great than lower ration
-	// we can check pool, but don't have enough free connections
-	// that will be useful for processing incoming requests leave check.
+// pendingCheck checks number of acquires waiting for connection.
+func pendingCheck(pending int64, cfg pool_config) error {
+	if cfg.maxPending > 0 && pending > cfg.maxPending {
+		return fmt.Errorf("%w: %d pending acquires exceed limit %d", ErrPoolNotReady, pending, cfg.maxPending)
+	}
+	return nil
+}
 
-	if max == 0 {
-		return check(ctx)
+// AcquireStats is cumulative statistics of waiting for connections from pool.
+type AcquireStats struct {
+	// Waits is number of acquires, which had to wait for connection.
+	Waits int64
+	// WaitTime is total time spent on waiting for connection.
+	WaitTime time.Duration
+	// Acquires is number of all acquires, if pool measures WaitTime over all of them
+	// rather than only over Waits, zero otherwise.
+	Acquires int64
+}
+
+// acquireMonitor decides readiness of pool by growth of acquire statistics
+// since previous check, so it doesn't take connections from pool.
+type acquireMonitor struct {
+	cfg pool_config
+
+	mu   sync.Mutex
+	prev AcquireStats
+}
+
+func newAcquireMonitor(cfg pool_config, initial AcquireStats) *acquireMonitor {
+	return &acquireMonitor{cfg: cfg, prev: initial}
+}
+
+// check compares given statistics with statistics of previous check
+// and puts number of waits and average wait time to details.
+func (m *acquireMonitor) check(stats AcquireStats, details map[string]any) error {
+	m.mu.Lock()
+	waits := stats.Waits - m.prev.Waits
+	waitTime := stats.WaitTime - m.prev.WaitTime
+	acquires := stats.Acquires - m.prev.Acquires
+	m.prev = stats
+	m.mu.Unlock()
+
+	// NOTE: if wait time is measured over all acquires, it's averaged over all of them,
+	// otherwise fast acquires would be counted as waits.
+	if stats.Acquires == 0 {
+		acquires = waits
 	}
 
-	ratioFree := idle / max * 100
+	var wait time.Duration
+	if acquires > 0 {
+		wait = waitTime / time.Duration(acquires)
+	}
 
-	if ratioFree > lower {
-		return check(ctx)
+	details["acquire_waits"] = waits
+	details["acquire_wait"] = wait.String()
+
+	if m.cfg.maxAcquireWaits > 0 && waits > m.cfg.maxAcquireWaits {
+		return fmt.Errorf("%w: %d waits for connection exceed limit %d", ErrPoolNotReady, waits, m.cfg.maxAcquireWaits)
+	}
+	if m.cfg.maxAcquireWait > 0 && wait > m.cfg.maxAcquireWait {
+		return fmt.Errorf("%w: average wait for connection %s exceeds limit %s", ErrPoolNotReady, wait, m.cfg.maxAcquireWait)
 	}
 
-	return ErrPoolNotReady
+	return nil
 }
@@ -0,0 +1,253 @@
+package checkers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moeryomenko/healing"
+)
+
+func TestAcquireMonitor(t *testing.T) {
+	cfg := newPoolConfig(WithPassiveReadiness(), WithMaxAcquireWait(10*time.Millisecond), WithMaxAcquireWaits(5))
+	monitor := newAcquireMonitor(cfg, AcquireStats{Waits: 100, WaitTime: time.Minute})
+
+	testcases := []struct {
+		name    string
+		stats   AcquireStats
+		waits   int64
+		wait    string
+		healthy bool
+	}{
+		{
+			name:    "history before start is ignored",
+			stats:   AcquireStats{Waits: 100, WaitTime: time.Minute},
+			waits:   0,
+			wait:    "0s",
+			healthy: true,
+		},
+		{
+			name:    "short waits",
+			stats:   AcquireStats{Waits: 102, WaitTime: time.Minute + 10*time.Millisecond},
+			waits:   2,
+			wait:    "5ms",
+			healthy: true,
+		},
+		{
+			name:    "long waits",
+			stats:   AcquireStats{Waits: 104, WaitTime: time.Minute + 50*time.Millisecond},
+			waits:   2,
+			wait:    "20ms",
+			healthy: false,
+		},
+		{
+			name:    "too many short waits",
+			stats:   AcquireStats{Waits: 110, WaitTime: time.Minute + 56*time.Millisecond},
+			waits:   6,
+			wait:    "1ms",
+			healthy: false,
+		},
+	}
+	for _, tc := range testcases {
+		details := map[string]any{}
+		err := monitor.check(tc.stats, details)
+		if tc.healthy {
+			assert.NoError(t, err, tc.name)
+		} else {
+			assert.ErrorIs(t, err, ErrPoolNotReady, tc.name)
+		}
+		assert.Equal(t, tc.waits, details["acquire_waits"], tc.name)
+		assert.Equal(t, tc.wait, details["acquire_wait"], tc.name)
+	}
+}
+
+func TestPoolCheck(t *testing.T) {
+	testcases := []struct {
+		name    string
+		stats   PoolStats
+		opts    []PoolOptions
+		healthy bool
+	}{
+		{
+			name:    "unlimited pool",
+			stats:   PoolStats{InUse: 100},
+			healthy: true,
+		},
+		{
+			name:    "partially idle pool",
+			stats:   PoolStats{Idle: 5, InUse: 5, Total: 10, Max: 10},
+			healthy: true,
+		},
+		{
+			name:    "not opened connections are free",
+			stats:   PoolStats{Idle: 0, InUse: 2, Total: 2, Max: 10},
+			healthy: true,
+		},
+		{
+			name:    "exhausted pool",
+			stats:   PoolStats{Idle: 0, InUse: 10, Total: 10, Max: 10},
+			healthy: false,
+		},
+		{
+			name:    "free percent at lower limit",
+			stats:   PoolStats{Idle: 1, InUse: 19, Total: 20, Max: 20},
+			healthy: false,
+		},
+		{
+			name:    "free percent above lower limit",
+			stats:   PoolStats{Idle: 2, InUse: 18, Total: 20, Max: 20},
+			healthy: true,
+		},
+		{
+			name:    "custom percent limit",
+			stats:   PoolStats{Idle: 5, InUse: 15, Total: 20, Max: 20},
+			opts:    []PoolOptions{WithLowerLimitPool(25)},
+			healthy: false,
+		},
+		{
+			name:    "absolute limit",
+			stats:   PoolStats{Idle: 10, InUse: 90, Total: 100, Max: 100},
+			opts:    []PoolOptions{WithMinFreeConns(20)},
+			healthy: false,
+		},
+		{
+			name:    "pending acquires within limit",
+			stats:   PoolStats{Idle: 5, InUse: 5, Total: 10, Max: 10, Pending: 5},
+			opts:    []PoolOptions{WithMaxPendingAcquires(5)},
+			healthy: true,
+		},
+		{
+			name:    "too many pending acquires",
+			stats:   PoolStats{Idle: 5, InUse: 5, Total: 10, Max: 10, Pending: 6},
+			opts:    []PoolOptions{WithMaxPendingAcquires(5)},
+			healthy: false,
+		},
+		{
+			name:    "pending acquires of unlimited pool",
+			stats:   PoolStats{InUse: 100, Pending: 6},
+			opts:    []PoolOptions{WithMaxPendingAcquires(5)},
+			healthy: false,
+		},
+		{
+			name:    "single connection pool",
+			stats:   PoolStats{Idle: 1, Total: 1, Max: 1},
+			healthy: true,
+		},
+	}
+	for _, testcase := range testcases {
+		tc := testcase
+		t.Run(tc.name, func(t *testing.T) {
+			err := poolCheck(tc.stats, newPoolConfig(tc.opts...))
+			if tc.healthy {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, ErrPoolNotReady)
+			}
+		})
+	}
+}
+
+func TestPoolReadinessProber(t *testing.T) {
+	stats := PoolStats{Idle: 5, InUse: 5, Total: 10, Max: 10, Acquire: &AcquireStats{}}
+	checks := 0
+	check := func(context.Context) error {
+		checks++
+		return nil
+	}
+
+	active := PoolReadinessProber(func() PoolStats { return stats }, check, WithMaxAcquireWait(100*time.Millisecond))
+	passive := PoolReadinessProber(func() PoolStats { return stats }, check, WithMaxAcquireWait(100*time.Millisecond), WithPassiveReadiness())
+	// NOTE: wait time isn't limited by default.
+	unlimited := PoolReadinessProber(func() PoolStats { return stats }, check)
+
+	res := active(context.Background())
+	assert.Equal(t, healing.UP, res.Status)
+	assert.Equal(t, 5, res.Details["in_use_conns"])
+	assert.Equal(t, 1, checks)
+
+	res = passive(context.Background())
+	assert.Equal(t, healing.UP, res.Status)
+	assert.Equal(t, 1, checks)
+
+	// NOTE: acquires wait for connections too long.
+	stats.Acquire = &AcquireStats{Waits: 2, WaitTime: time.Second}
+
+	res = active(context.Background())
+	assert.Equal(t, healing.DOWN, res.Status)
+	assert.ErrorIs(t, res.Error, ErrPoolNotReady)
+	assert.Equal(t, "500ms", res.Details["acquire_wait"])
+	assert.Equal(t, 1, checks)
+
+	res = passive(context.Background())
+	assert.Equal(t, healing.DOWN, res.Status)
+
+	res = unlimited(context.Background())
+	assert.Equal(t, healing.UP, res.Status)
+	assert.Equal(t, "500ms", res.Details["acquire_wait"])
+	assert.Equal(t, int64(2), res.Details["acquire_waits"])
+
+	waits := PoolReadinessProber(func() PoolStats { return stats }, check, WithMaxAcquireWaits(1))
+	stats.Acquire = &AcquireStats{Waits: 4, WaitTime: time.Second}
+	res = waits(context.Background())
+	assert.Equal(t, healing.DOWN, res.Status)
+	assert.ErrorIs(t, res.Error, ErrPoolNotReady)
+	assert.Equal(t, int64(2), res.Details["acquire_waits"])
+
+	// NOTE: pending acquires aren't estimated by waits, they are checked
+	// only if pool exposes them.
+	pending := PoolReadinessProber(func() PoolStats { return stats }, check, WithMaxPendingAcquires(1))
+	stats.Acquire = &AcquireStats{Waits: 10, WaitTime: time.Second}
+	res = pending(context.Background())
+	assert.Equal(t, healing.UP, res.Status)
+	assert.NotContains(t, res.Details, "pending_acquires")
+
+	stats.Pending = 2
+	res = pending(context.Background())
+	assert.Equal(t, healing.DOWN, res.Status)
+	assert.Equal(t, int64(2), res.Details["pending_acquires"])
+}
+
+func TestPoolLivenessProber(t *testing.T) {
+	var (
+		pings int
+		err   error
+	)
+	ping := func(context.Context) error {
+		pings++
+		return err
+	}
+
+	liveness := PoolLivenessProber(ping)
+
+	res := liveness(context.Background())
+	assert.Equal(t, healing.UP, res.Status)
+
+	// NOTE: by default pool is pinged on every check.
+	err = ErrPoolNotReady
+	res = liveness(context.Background())
+	assert.Equal(t, healing.DOWN, res.Status)
+	assert.ErrorIs(t, res.Error, ErrPoolNotReady)
+	assert.Equal(t, 2, pings)
+
+	pings, err = 0, nil
+	liveness = PoolLivenessProber(ping, WithPoolLivenessPeriod(time.Hour))
+
+	res = liveness(context.Background())
+	assert.Equal(t, healing.UP, res.Status)
+
+	// NOTE: result of last ping is reused until period passes.
+	err = ErrPoolNotReady
+	res = liveness(context.Background())
+	assert.Equal(t, healing.UP, res.Status)
+	assert.Equal(t, 1, pings)
+
+	liveness = PoolLivenessProber(ping, WithPoolLivenessPeriod(time.Hour))
+	for i := 0; i < 2; i++ {
+		res = liveness(context.Background())
+		assert.Equal(t, healing.DOWN, res.Status)
+		assert.ErrorIs(t, res.Error, ErrPoolNotReady)
+	}
+	assert.Equal(t, 2, pings)
+}
@@ -0,0 +1,183 @@
+package checkers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moeryomenko/healing"
+)
+
+// NOTE: only beginning of body is read, it's enough for health responses.
+const maxHTTPBody = 1 << 20
+
+// maxHTTPDrain is limit of unread rest of body, which is discarded to reuse connection,
+// connection with longer body is closed.
+const maxHTTPDrain = 1 << 20
+
+var (
+	// ErrHTTPStatus indicates that status code of response is unexpected.
+	ErrHTTPStatus = errors.New("unexpected http status")
+	// ErrHTTPBody indicates that body of response doesn't match expectations.
+	ErrHTTPBody = errors.New("unexpected http body")
+)
+
+// HTTPProber returns checker function of HTTP dependency, which sends request to given url
+// and checks status code and body of response. Latency and status code of response
+// are reported in details of check result.
+func HTTPProber(url string, opts ...HTTPOptions) healing.CheckerFunc {
+	cfg := newHTTPConfig(opts...)
+
+	client := http.DefaultClient
+	if cfg.tlsConfig != nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = cfg.tlsConfig
+		client = &http.Client{Transport: transport}
+	}
+
+	return func(ctx context.Context) healing.CheckResult {
+		if cfg.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+			defer cancel()
+		}
+
+		details := make(map[string]any)
+		res := CheckHelper(func() error {
+			req, err := http.NewRequestWithContext(ctx, cfg.method, url, nil)
+			if err != nil {
+				return err
+			}
+			req.Header = cfg.header.Clone()
+			// NOTE: client ignores Host header, it's sent from Host field of request.
+			if host := cfg.header.Get("Host"); host != "" {
+				req.Host = host
+			}
+
+			start := time.Now()
+			resp, err := client.Do(req)
+			if err != nil {
+				return err
+			}
+			defer func() {
+				io.Copy(io.Discard, io.LimitReader(resp.Body, maxHTTPDrain))
+				resp.Body.Close()
+			}()
+
+			body, err := io.ReadAll(io.LimitReader(resp.Body, maxHTTPBody))
+			details["latency"] = time.Since(start).String()
+			details["status_code"] = resp.StatusCode
+			if err != nil {
+				return err
+			}
+
+			if !expectedStatus(resp.StatusCode, cfg.codes) {
+				return fmt.Errorf("%w: %d", ErrHTTPStatus, resp.StatusCode)
+			}
+			for _, match := range cfg.matchers {
+				if err := match(body); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		res.Details = details
+		return res
+	}
+}
+
+func expectedStatus(code int, codes []int) bool {
+	if len(codes) == 0 {
+		return code >= http.StatusOK && code < http.StatusMultipleChoices
+	}
+	return slices.Contains(codes, code)
+}
+
+func bodyContains(substr string) func([]byte) error {
+	return func(body []byte) error {
+		if !bytes.Contains(body, []byte(substr)) {
+			return fmt.Errorf("%w: doesn't contain %q", ErrHTTPBody, substr)
+		}
+		return nil
+	}
+}
+
+func bodyRegexp(re *regexp.Regexp) func([]byte) error {
+	return func(body []byte) error {
+		if !re.Match(body) {
+			return fmt.Errorf("%w: doesn't match %q", ErrHTTPBody, re)
+		}
+		return nil
+	}
+}
+
+func bodyJSONPath(path, expected string) func([]byte) error {
+	return func(body []byte) error {
+		var doc any
+		if err := json.Unmarshal(body, &doc); err != nil {
+			return fmt.Errorf("%w: %w", ErrHTTPBody, err)
+		}
+
+		value, err := jsonPath(doc, path)
+		if err != nil {
+			return fmt.Errorf("%w: %w", ErrHTTPBody, err)
+		}
+		if actual := fmt.Sprint(value); actual != expected {
+			return fmt.Errorf("%w: %s is %q, expected %q", ErrHTTPBody, path, actual, expected)
+		}
+		return nil
+	}
+}
+
+// jsonPath returns value of decoded JSON document at simple path of fields
+// and array indexes, like `$.checks[0].status`.
+func jsonPath(doc any, path string) (any, error) {
+	rest := strings.TrimPrefix(path, "$")
+	value := doc
+
+	for rest != "" {
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[") + 1
+			if end == 0 {
+				end = len(rest)
+			}
+			object, ok := value.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%s: %q isn't object", path, rest)
+			}
+			if value, ok = object[rest[1:end]]; !ok {
+				return nil, fmt.Errorf("%s: field %q not found", path, rest[1:end])
+			}
+			rest = rest[end:]
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, fmt.Errorf("%s: unclosed index", path)
+			}
+			index, err := strconv.Atoi(rest[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("%s: invalid index %q", path, rest[1:end])
+			}
+			array, ok := value.([]any)
+			if !ok || index < 0 || index >= len(array) {
+				return nil, fmt.Errorf("%s: index %d not found", path, index)
+			}
+			value = array[index]
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("%s: invalid path", path)
+		}
+	}
+
+	return value, nil
+}
@@ -0,0 +1,190 @@
+package checkers
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moeryomenko/healing"
+)
+
+func TestHTTPProber(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			if r.Header.Get("Authorization") != "Bearer token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"status":"UP","checks":[{"name":"db","status":"DOWN"}],"version":2}`))
+		case "/vhost":
+			if r.Host != "api.internal" {
+				w.WriteHeader(http.StatusMisdirectedRequest)
+			}
+		case "/slow":
+			time.Sleep(100 * time.Millisecond)
+		case "/created":
+			if r.Method != http.MethodPost {
+				w.WriteHeader(http.StatusMethodNotAllowed)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	auth := WithHTTPHeader("Authorization", "Bearer token")
+
+	testcases := []struct {
+		name    string
+		path    string
+		opts    []HTTPOptions
+		code    int
+		healthy bool
+		err     error
+	}{
+		{
+			name:    "healthy",
+			path:    "/health",
+			opts:    []HTTPOptions{auth, WithHTTPBodyContains(`"UP"`), WithHTTPBodyRegexp(regexp.MustCompile(`"version":\d+`))},
+			code:    http.StatusOK,
+			healthy: true,
+		},
+		{
+			name: "missing header",
+			path: "/health",
+			code: http.StatusUnauthorized,
+			err:  ErrHTTPStatus,
+		},
+		{
+			name:    "json path",
+			path:    "/health",
+			opts:    []HTTPOptions{auth, WithHTTPBodyJSONPath("$.status", "UP"), WithHTTPBodyJSONPath("$.version", "2")},
+			code:    http.StatusOK,
+			healthy: true,
+		},
+		{
+			name: "json path mismatch",
+			path: "/health",
+			opts: []HTTPOptions{auth, WithHTTPBodyJSONPath("$.checks[0].status", "UP")},
+			code: http.StatusOK,
+			err:  ErrHTTPBody,
+		},
+		{
+			name: "body mismatch",
+			path: "/health",
+			opts: []HTTPOptions{auth, WithHTTPBodyContains("DEGRADED")},
+			code: http.StatusOK,
+			err:  ErrHTTPBody,
+		},
+		{
+			name:    "method and status",
+			path:    "/created",
+			opts:    []HTTPOptions{WithHTTPMethod(http.MethodPost), WithHTTPStatus(http.StatusCreated)},
+			code:    http.StatusCreated,
+			healthy: true,
+		},
+		{
+			name:    "virtual host",
+			path:    "/vhost",
+			opts:    []HTTPOptions{WithHTTPHeader("Host", "api.internal")},
+			code:    http.StatusOK,
+			healthy: true,
+		},
+		{
+			name: "unexpected status",
+			path: "/missing",
+			code: http.StatusNotFound,
+			err:  ErrHTTPStatus,
+		},
+		{
+			name: "timeout",
+			path: "/slow",
+			opts: []HTTPOptions{WithHTTPTimeout(10 * time.Millisecond)},
+			err:  context.DeadlineExceeded,
+		},
+	}
+	for _, testcase := range testcases {
+		tc := testcase
+		t.Run(tc.name, func(t *testing.T) {
+			res := HTTPProber(server.URL+tc.path, tc.opts...)(context.Background())
+			if tc.healthy {
+				assert.Equal(t, healing.UP, res.Status, res.Error)
+			} else {
+				assert.Equal(t, healing.DOWN, res.Status)
+				assert.ErrorIs(t, res.Error, tc.err)
+			}
+			if tc.code != 0 {
+				assert.Equal(t, tc.code, res.Details["status_code"])
+				assert.Contains(t, res.Details, "latency")
+			}
+		})
+	}
+}
+
+func TestHTTPProber_TLS(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	res := HTTPProber(server.URL)(context.Background())
+	assert.Equal(t, healing.DOWN, res.Status)
+
+	transport, ok := server.Client().Transport.(*http.Transport)
+	require.True(t, ok)
+	res = HTTPProber(server.URL, WithHTTPTLSConfig(transport.TLSClientConfig))(context.Background())
+	assert.Equal(t, healing.UP, res.Status, res.Error)
+}
+
+func TestJSONPath(t *testing.T) {
+	doc := map[string]any{
+		"status": "UP",
+		"checks": []any{map[string]any{"name": "db"}},
+	}
+
+	value, err := jsonPath(doc, "$.status")
+	require.NoError(t, err)
+	assert.Equal(t, "UP", value)
+
+	value, err = jsonPath(doc, "$.checks[0].name")
+	require.NoError(t, err)
+	assert.Equal(t, "db", value)
+
+	for _, path := range []string{"$.missing", "$.checks[1]", "$.status.name", "$.checks[x]", "$.checks[0", "status"} {
+		_, err = jsonPath(doc, path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestHTTPProber_KeepAlive(t *testing.T) {
+	var conns atomic.Int64
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// NOTE: body is longer than read part, so rest of it must be drained.
+		w.Write(bytes.Repeat([]byte{'x'}, maxHTTPBody+maxHTTPBody/2))
+	}))
+	server.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			conns.Add(1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	probe := HTTPProber(server.URL)
+	for i := 0; i < 3; i++ {
+		res := probe(context.Background())
+		assert.Equal(t, healing.UP, res.Status, res.Error)
+	}
+	assert.Equal(t, int64(1), conns.Load())
+}
@@ -0,0 +1,149 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/moeryomenko/healing"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+var (
+	// ErrKafkaNoBrokers indicates that metadata of kafka cluster has no brokers.
+	ErrKafkaNoBrokers = errors.New("kafka brokers are unavailable")
+	// ErrKafkaTopic indicates that required topic is missing or has unexpected partitions.
+	ErrKafkaTopic = errors.New("kafka topic is not ready")
+	// ErrKafkaConsumerLag indicates that consumer group falls too far behind.
+	ErrKafkaConsumerLag = errors.New("kafka consumer lag exceeds limit")
+)
+
+// KafkaProbes returns liveness and readiness probes for franz-go client.
+// Liveness fetches metadata of brokers, readiness checks topics required by WithKafkaTopic
+// and lag of consumer groups limited by WithKafkaConsumerLag. Partitions of topics and lag
+// of groups are reported in details of check result.
+func KafkaProbes(client *kgo.Client, opts ...KafkaOptions) (
+	liveness, readiness healing.CheckerFunc,
+) {
+	adm := kadm.NewClient(client)
+
+	return func(ctx context.Context) healing.CheckResult {
+		return CheckHelper(func() error {
+			metadata, err := adm.BrokerMetadata(ctx)
+			if err != nil {
+				return err
+			}
+			if len(metadata.Brokers) == 0 {
+				return ErrKafkaNoBrokers
+			}
+			return nil
+		})
+	}, KafkaReadinessProber(client, opts...)
+}
+
+// KafkaReadinessProber returns kafka readiness checker function,
+// for more details see KafkaProbes.
+func KafkaReadinessProber(client *kgo.Client, opts ...KafkaOptions) healing.CheckerFunc {
+	cfg := newKafkaConfig(opts...)
+	adm := kadm.NewClient(client)
+
+	topics := make([]string, 0, len(cfg.topics))
+	for topic := range cfg.topics {
+		topics = append(topics, topic)
+	}
+	sort.Strings(topics)
+
+	groups := make([]string, 0, len(cfg.groups))
+	for group := range cfg.groups {
+		groups = append(groups, group)
+	}
+
+	return func(ctx context.Context) healing.CheckResult {
+		details := make(map[string]any)
+		res := CheckHelper(func() error {
+			var metadata kadm.Metadata
+			var err error
+			// NOTE: metadata of all topics isn't needed, if no topics are required.
+			if len(topics) == 0 {
+				metadata, err = adm.BrokerMetadata(ctx)
+			} else {
+				metadata, err = adm.Metadata(ctx, topics...)
+			}
+			if err != nil {
+				return err
+			}
+			if len(metadata.Brokers) == 0 {
+				return ErrKafkaNoBrokers
+			}
+			details["brokers"] = len(metadata.Brokers)
+
+			errs := []error{checkKafkaTopics(metadata.Topics, cfg.topics, details)}
+
+			if len(groups) > 0 {
+				lags, err := adm.Lag(ctx, groups...)
+				if err != nil {
+					return errors.Join(append(errs, err)...)
+				}
+				errs = append(errs, checkKafkaLags(lags, cfg.groups, details))
+			}
+
+			return errors.Join(errs...)
+		})
+		res.Details = details
+		return res
+	}
+}
+
+// checkKafkaTopics checks that required topics exist with expected number of partitions.
+func checkKafkaTopics(topics kadm.TopicDetails, required map[string]int, details map[string]any) error {
+	var errs []error
+	partitions := make(map[string]int, len(required))
+
+	for topic, expected := range required {
+		detail, ok := topics[topic]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("%w: %s is missing", ErrKafkaTopic, topic))
+		case detail.Err != nil:
+			errs = append(errs, fmt.Errorf("%w: %s: %w", ErrKafkaTopic, topic, detail.Err))
+		default:
+			partitions[topic] = len(detail.Partitions)
+			if expected > 0 && len(detail.Partitions) != expected {
+				errs = append(errs, fmt.Errorf("%w: %s has %d partitions, expected %d",
+					ErrKafkaTopic, topic, len(detail.Partitions), expected))
+			}
+		}
+	}
+
+	if len(required) > 0 {
+		details["topic_partitions"] = partitions
+	}
+	return errors.Join(errs...)
+}
+
+// checkKafkaLags checks that total lag of every consumer group is within limit.
+func checkKafkaLags(lags kadm.DescribedGroupLags, limits map[string]int64, details map[string]any) error {
+	var errs []error
+	totals := make(map[string]int64, len(limits))
+
+	for group, limit := range limits {
+		lag, ok := lags[group]
+		switch {
+		case !ok:
+			errs = append(errs, fmt.Errorf("%w: lag of %s is unknown", ErrKafkaConsumerLag, group))
+		case lag.Error() != nil:
+			errs = append(errs, fmt.Errorf("%s: %w", group, lag.Error()))
+		default:
+			total := lag.Lag.Total()
+			totals[group] = total
+			if total > limit {
+				errs = append(errs, fmt.Errorf("%w: %s lag %d > %d", ErrKafkaConsumerLag, group, total, limit))
+			}
+		}
+	}
+
+	details["consumer_lag"] = totals
+	return errors.Join(errs...)
+}
@@ -0,0 +1,61 @@
+package checkers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/moeryomenko/healing"
+)
+
+func TestKafkaProbes(t *testing.T) {
+	ctx := context.Background()
+
+	cluster, err := kfake.NewCluster(kfake.NumBrokers(1), kfake.SeedTopics(3, "orders"))
+	require.NoError(t, err)
+	defer cluster.Close()
+
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(cluster.ListenAddrs()...),
+		kgo.RecordPartitioner(kgo.ManualPartitioner()),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	// NOTE: produce records and commit offsets of consumer group behind them.
+	for i := 0; i < 10; i++ {
+		require.NoError(t, client.ProduceSync(ctx, &kgo.Record{Topic: "orders", Partition: 0, Value: []byte("order")}).FirstErr())
+	}
+	offsets := make(kadm.Offsets)
+	offsets.Add(kadm.Offset{Topic: "orders", Partition: 0, At: 4, LeaderEpoch: -1})
+	_, err = kadm.NewClient(client).CommitOffsets(ctx, "billing", offsets)
+	require.NoError(t, err)
+
+	liveness, readiness := KafkaProbes(client,
+		WithKafkaTopic("orders", 3),
+		WithKafkaConsumerLag("billing", 6),
+	)
+
+	res := liveness(ctx)
+	assert.Equal(t, healing.UP, res.Status)
+
+	res = readiness(ctx)
+	assert.Equal(t, healing.UP, res.Status, res.Error)
+	assert.Equal(t, map[string]int{"orders": 3}, res.Details["topic_partitions"])
+	assert.Equal(t, map[string]int64{"billing": 6}, res.Details["consumer_lag"])
+
+	res = KafkaReadinessProber(client, WithKafkaConsumerLag("billing", 5))(ctx)
+	assert.Equal(t, healing.DOWN, res.Status)
+	assert.ErrorIs(t, res.Error, ErrKafkaConsumerLag)
+
+	res = KafkaReadinessProber(client, WithKafkaTopic("orders", 1), WithKafkaTopic("payments", 0))(ctx)
+	assert.Equal(t, healing.DOWN, res.Status)
+	assert.ErrorIs(t, res.Error, ErrKafkaTopic)
+	assert.ErrorContains(t, res.Error, "payments")
+	assert.ErrorContains(t, res.Error, "orders has 3 partitions, expected 1")
+}
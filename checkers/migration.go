@@ -0,0 +1,74 @@
+package checkers
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/moeryomenko/healing"
+)
+
+const (
+	migrateTable = "schema_migrations"
+	gooseTable   = "goose_db_version"
+)
+
+var (
+	// ErrMigrationPending indicates that schema version is below required.
+	ErrMigrationPending = errors.New("schema migration is pending")
+	// ErrMigrationDirty indicates that last migration has failed and schema is dirty.
+	ErrMigrationDirty = errors.New("schema is dirty")
+)
+
+// MigrateVersionProber returns checker function, which fails while version of schema
+// migrated by golang-migrate is below given minimum or schema is dirty.
+func MigrateVersionProber(db *sql.DB, minVersion int64, opts ...MigrationOptions) healing.CheckerFunc {
+	cfg := newMigrationConfig(migrateTable, opts...)
+	query := fmt.Sprintf("SELECT version, dirty FROM %s LIMIT 1", cfg.table)
+
+	return SQLQueryProber(db, query, func(rows *sql.Rows) error {
+		var state migrationState
+		if rows.Next() {
+			if err := rows.Scan(&state.version, &state.dirty); err != nil {
+				return err
+			}
+		}
+		return state.check(minVersion)
+	}, WithQueryTimeout(cfg.timeout))
+}
+
+// GooseVersionProber returns checker function, which fails while version of schema
+// migrated by goose is below given minimum.
+func GooseVersionProber(db *sql.DB, minVersion int64, opts ...MigrationOptions) healing.CheckerFunc {
+	cfg := newMigrationConfig(gooseTable, opts...)
+	// NOTE: goose appends row on every apply and rollback of migration,
+	// so version is applied only if its latest row is applied.
+	query := fmt.Sprintf(`SELECT COALESCE(MAX(version_id), 0) FROM %[1]s v
+		WHERE is_applied AND id = (SELECT MAX(id) FROM %[1]s WHERE version_id = v.version_id)`, cfg.table)
+
+	return SQLQueryProber(db, query, func(rows *sql.Rows) error {
+		var state migrationState
+		if rows.Next() {
+			if err := rows.Scan(&state.version); err != nil {
+				return err
+			}
+		}
+		return state.check(minVersion)
+	}, WithQueryTimeout(cfg.timeout))
+}
+
+// migrationState is state of schema migrations.
+type migrationState struct {
+	version int64
+	dirty   bool
+}
+
+func (s migrationState) check(minVersion int64) error {
+	switch {
+	case s.dirty:
+		return fmt.Errorf("%w: version %d", ErrMigrationDirty, s.version)
+	case s.version < minVersion:
+		return fmt.Errorf("%w: version %d < %d", ErrMigrationPending, s.version, minVersion)
+	}
+	return nil
+}
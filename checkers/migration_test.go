@@ -0,0 +1,32 @@
+package checkers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrationState(t *testing.T) {
+	testcases := []struct {
+		name  string
+		state migrationState
+		err   error
+	}{
+		{name: "migrated", state: migrationState{version: 10}},
+		{name: "ahead of required", state: migrationState{version: 12}},
+		{name: "pending", state: migrationState{version: 9}, err: ErrMigrationPending},
+		{name: "not migrated", state: migrationState{}, err: ErrMigrationPending},
+		{name: "dirty", state: migrationState{version: 10, dirty: true}, err: ErrMigrationDirty},
+	}
+	for _, testcase := range testcases {
+		tc := testcase
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.state.check(10)
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
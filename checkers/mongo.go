@@ -0,0 +1,198 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/moeryomenko/healing"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+var (
+	// ErrMongoNoPrimary indicates that primary of mongodb deployment is unavailable.
+	ErrMongoNoPrimary = errors.New("mongodb primary is unavailable")
+	// ErrMongoUnhealthyMembers indicates that some members of replica set are unhealthy.
+	ErrMongoUnhealthyMembers = errors.New("mongodb replica set members are unhealthy")
+)
+
+// MongoProbes returns liveness and readiness probes for mongo client. Liveness pings
+// server selected by read preference. Readiness requires primary to be available and,
+// for replica set, all members to be healthy, which requires clusterMonitor role.
+func MongoProbes(client *mongo.Client, opts ...MongoOptions) (
+	liveness, readiness healing.CheckerFunc,
+) {
+	cfg := newMongoConfig(opts...)
+
+	return func(ctx context.Context) healing.CheckResult {
+		return CheckHelper(func() error { return client.Ping(ctx, cfg.readPref) })
+	}, MongoReadinessProber(client, opts...)
+}
+
+// MongoReadinessProber returns mongo client readiness checker function,
+// for more details see MongoProbes.
+func MongoReadinessProber(client *mongo.Client, opts ...MongoOptions) healing.CheckerFunc {
+	cfg := newMongoConfig(opts...)
+
+	var monitor *acquireMonitor
+	if cfg.monitor != nil {
+		monitor = newAcquireMonitor(cfg.pool, cfg.monitor.Stats())
+	}
+
+	admin := client.Database("admin")
+
+	return func(ctx context.Context) healing.CheckResult {
+		details := make(map[string]any)
+		res := CheckHelper(func() error {
+			var err error
+			if monitor != nil {
+				err = monitor.check(cfg.monitor.Stats(), details)
+			}
+
+			return errors.Join(err, mongoTopologyCheck(ctx, admin.RunCommand, details))
+		})
+		res.Details = details
+		return res
+	}
+}
+
+// mongoRunCommand runs command against database, it has signature of mongo.Database.RunCommand.
+type mongoRunCommand func(ctx context.Context, cmd any, opts ...*options.RunCmdOptions) *mongo.SingleResult
+
+// mongoTopologyCheck fetches topology of deployment by hello and replSetGetStatus commands
+// and checks it by checkMongoTopology.
+func mongoTopologyCheck(ctx context.Context, run mongoRunCommand, details map[string]any) error {
+	// NOTE: commands with default primary read preference block on server selection
+	// when there is no primary, so readiness would fail by timeout instead of reply.
+	opts := options.RunCmd().SetReadPreference(readpref.PrimaryPreferred())
+
+	var hello mongoHello
+	if helloErr := run(ctx, bson.D{{Key: "hello", Value: 1}}, opts).Decode(&hello); helloErr != nil {
+		// NOTE: servers before 4.4.2 support only legacy isMaster command.
+		if legacyErr := run(ctx, bson.D{{Key: "isMaster", Value: 1}}, opts).Decode(&hello); legacyErr != nil {
+			return helloErr
+		}
+	}
+
+	var members []mongoMember
+	if hello.SetName != "" {
+		var status struct {
+			Members []mongoMember `bson:"members"`
+		}
+		if err := run(ctx, bson.D{{Key: "replSetGetStatus", Value: 1}}, opts).Decode(&status); err != nil {
+			return err
+		}
+		members = status.Members
+	}
+
+	return checkMongoTopology(hello, members, details)
+}
+
+// mongoHello is reply of hello and legacy isMaster commands.
+type mongoHello struct {
+	IsWritablePrimary bool   `bson:"isWritablePrimary"`
+	IsMaster          bool   `bson:"ismaster"`
+	SetName           string `bson:"setName"`
+	Primary           string `bson:"primary"`
+}
+
+// mongoMember is member of replica set in reply of replSetGetStatus command.
+type mongoMember struct {
+	Name   string  `bson:"name"`
+	Health float64 `bson:"health"`
+	State  string  `bson:"stateStr"`
+}
+
+// checkMongoTopology checks that primary is available and members of replica set
+// are healthy, and fills details of check result.
+func checkMongoTopology(hello mongoHello, members []mongoMember, details map[string]any) error {
+	writable := hello.IsWritablePrimary || hello.IsMaster
+	if hello.SetName != "" {
+		details["set_name"] = hello.SetName
+		details["primary"] = hello.Primary
+	}
+
+	if !writable && hello.Primary == "" {
+		return ErrMongoNoPrimary
+	}
+	if len(members) == 0 {
+		return nil
+	}
+
+	states := make(map[string]string, len(members))
+	var unhealthy []string
+	for _, member := range members {
+		states[member.Name] = member.State
+		// NOTE: members in recovery, startup or rollback can't serve requests.
+		healthy := member.State == "PRIMARY" || member.State == "SECONDARY" || member.State == "ARBITER"
+		if member.Health != 1 || !healthy {
+			unhealthy = append(unhealthy, member.Name)
+		}
+	}
+	details["members"] = states
+
+	if len(unhealthy) > 0 {
+		sort.Strings(unhealthy)
+		return fmt.Errorf("%w: %v", ErrMongoUnhealthyMembers, unhealthy)
+	}
+	return nil
+}
+
+// MongoPoolMonitor collects statistics of connection checkouts from pool of mongo driver,
+// pool monitor of driver must be set by PoolMonitor. Driver doesn't report whether checkout
+// waited for connection, so only checkouts longer than threshold are counted as waits.
+type MongoPoolMonitor struct {
+	next      *event.PoolMonitor
+	threshold time.Duration
+
+	waits    atomic.Int64
+	waitTime atomic.Int64
+}
+
+// defaultMongoWaitThreshold is duration of checkout, above which checkout is considered
+// as waiting for connection.
+const defaultMongoWaitThreshold = time.Millisecond
+
+// NewMongoPoolMonitor returns new instance of MongoPoolMonitor, events are passed to given
+// monitor if it isn't nil. Checkouts longer than threshold are counted as waits,
+// if threshold isn't positive, 1ms is used.
+func NewMongoPoolMonitor(next *event.PoolMonitor, threshold time.Duration) *MongoPoolMonitor {
+	if threshold <= 0 {
+		threshold = defaultMongoWaitThreshold
+	}
+	return &MongoPoolMonitor{next: next, threshold: threshold}
+}
+
+// PoolMonitor returns pool monitor for options of mongo client.
+func (m *MongoPoolMonitor) PoolMonitor() *event.PoolMonitor {
+	return &event.PoolMonitor{Event: m.event}
+}
+
+// Stats returns cumulative statistics of connection checkouts, which waited for connection.
+func (m *MongoPoolMonitor) Stats() AcquireStats {
+	return AcquireStats{
+		Waits:    m.waits.Load(),
+		WaitTime: time.Duration(m.waitTime.Load()),
+	}
+}
+
+func (m *MongoPoolMonitor) event(e *event.PoolEvent) {
+	switch e.Type {
+	case event.GetSucceeded, event.GetFailed:
+		if e.Duration > m.threshold {
+			m.waits.Add(1)
+			m.waitTime.Add(int64(e.Duration))
+		}
+	}
+
+	if m.next != nil && m.next.Event != nil {
+		m.next.Event(e)
+	}
+}
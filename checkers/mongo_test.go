@@ -0,0 +1,124 @@
+package checkers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/event"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
+
+func TestCheckMongoTopology(t *testing.T) {
+	testcases := []struct {
+		name    string
+		hello   mongoHello
+		members []mongoMember
+		err     error
+	}{
+		{
+			name:  "standalone",
+			hello: mongoHello{IsWritablePrimary: true},
+		},
+		{
+			name:  "legacy standalone",
+			hello: mongoHello{IsMaster: true},
+		},
+		{
+			name:  "replica set without primary",
+			hello: mongoHello{SetName: "rs0"},
+			err:   ErrMongoNoPrimary,
+		},
+		{
+			name:  "healthy replica set",
+			hello: mongoHello{SetName: "rs0", Primary: "mongo-0:27017"},
+			members: []mongoMember{
+				{Name: "mongo-0:27017", Health: 1, State: "PRIMARY"},
+				{Name: "mongo-1:27017", Health: 1, State: "SECONDARY"},
+				{Name: "mongo-2:27017", Health: 1, State: "ARBITER"},
+			},
+		},
+		{
+			name:  "unhealthy members",
+			hello: mongoHello{SetName: "rs0", Primary: "mongo-0:27017"},
+			members: []mongoMember{
+				{Name: "mongo-0:27017", Health: 1, State: "PRIMARY"},
+				{Name: "mongo-1:27017", Health: 0, State: "(not reachable/healthy)"},
+				{Name: "mongo-2:27017", Health: 1, State: "RECOVERING"},
+			},
+			err: ErrMongoUnhealthyMembers,
+		},
+	}
+	for _, testcase := range testcases {
+		tc := testcase
+		t.Run(tc.name, func(t *testing.T) {
+			details := map[string]any{}
+			err := checkMongoTopology(tc.hello, tc.members, details)
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+
+	details := map[string]any{}
+	err := checkMongoTopology(testcases[4].hello, testcases[4].members, details)
+	assert.EqualError(t, err, "mongodb replica set members are unhealthy: [mongo-1:27017 mongo-2:27017]")
+	assert.Equal(t, "mongo-0:27017", details["primary"])
+	assert.Equal(t, "RECOVERING", details["members"].(map[string]string)["mongo-2:27017"])
+}
+
+func TestMongoTopologyCheck(t *testing.T) {
+	replies := map[string]bson.M{
+		// NOTE: reply of secondary while election is in progress.
+		"hello": {"isWritablePrimary": false, "secondary": true, "setName": "rs0"},
+		"replSetGetStatus": {"members": bson.A{
+			bson.M{"name": "mongo-0:27017", "health": 0.0, "stateStr": "(not reachable/healthy)"},
+			bson.M{"name": "mongo-1:27017", "health": 1.0, "stateStr": "SECONDARY"},
+		}},
+	}
+
+	var commands []string
+	run := func(_ context.Context, cmd any, opts ...*options.RunCmdOptions) *mongo.SingleResult {
+		name := cmd.(bson.D)[0].Key
+		commands = append(commands, name)
+
+		rp := options.MergeRunCmdOptions(opts...).ReadPreference
+		if assert.NotNil(t, rp, name) {
+			assert.Equal(t, readpref.PrimaryPreferredMode, rp.Mode(), name)
+		}
+		return mongo.NewSingleResultFromDocument(replies[name], nil, nil)
+	}
+
+	details := map[string]any{}
+	err := mongoTopologyCheck(context.Background(), run, details)
+	assert.ErrorIs(t, err, ErrMongoNoPrimary)
+	assert.Equal(t, []string{"hello", "replSetGetStatus"}, commands)
+	assert.Equal(t, "rs0", details["set_name"])
+}
+
+func TestMongoPoolMonitor(t *testing.T) {
+	var forwarded int
+	monitor := NewMongoPoolMonitor(&event.PoolMonitor{Event: func(*event.PoolEvent) { forwarded++ }}, 0)
+	pool := monitor.PoolMonitor()
+
+	pool.Event(&event.PoolEvent{Type: event.GetStarted})
+	// NOTE: checkout of idle connection doesn't wait.
+	pool.Event(&event.PoolEvent{Type: event.GetSucceeded, Duration: 50 * time.Microsecond})
+	pool.Event(&event.PoolEvent{Type: event.GetSucceeded, Duration: time.Millisecond})
+	pool.Event(&event.PoolEvent{Type: event.GetSucceeded, Duration: 2 * time.Millisecond})
+	pool.Event(&event.PoolEvent{Type: event.GetFailed, Duration: 3 * time.Millisecond})
+	pool.Event(&event.PoolEvent{Type: event.ConnectionReturned})
+
+	assert.Equal(t, AcquireStats{Waits: 2, WaitTime: 5 * time.Millisecond}, monitor.Stats())
+	assert.Equal(t, 6, forwarded)
+
+	monitor = NewMongoPoolMonitor(nil, 10*time.Millisecond)
+	monitor.PoolMonitor().Event(&event.PoolEvent{Type: event.GetSucceeded, Duration: 5 * time.Millisecond})
+	assert.Equal(t, AcquireStats{}, monitor.Stats())
+}
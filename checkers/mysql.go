@@ -9,44 +9,33 @@ import (
 
 // MySQLProbes returns liveness and readiness probes for mysql pool.
 func MySQLProbes(pool *client.Pool, maxAlive int, opts ...PoolOptions) (
-	liveness, readiness func(context.Context) healing.CheckResult,
+	liveness, readiness healing.CheckerFunc,
 ) {
-	cfg := pool_config{lowerLimit: defaultLowerLimit, livenessPeriod: defaultPoolLivenessPeriod}
-
-	for _, opt := range opts {
-		opt(&cfg)
-	}
-
-	return func(ctx context.Context) healing.CheckResult {
-		return CheckHelper(checkPoolLiveness(ctx, cfg.livenessPeriod, func(ctx context.Context) error {
-			conn, err := pool.GetConn(ctx)
-			if err != nil {
-				return err
-			}
-			defer func() { pool.PutConn(conn) }()
-			return conn.Ping()
-		}))
-	}, MySQLReadinessProber(pool, maxAlive, opts...)
+	return PoolLivenessProber(func(ctx context.Context) error {
+		conn, err := pool.GetConn(ctx)
+		if err != nil {
+			return err
+		}
+		defer func() { pool.PutConn(conn) }()
+		return conn.Ping()
+	}, opts...), MySQLReadinessProber(pool, maxAlive, opts...)
 }
 
 // MySQLReadinessProber returns mysql conn pool readiness checker function.
-func MySQLReadinessProber(pool *client.Pool, maxAlive int, opts ...PoolOptions) func(context.Context) healing.CheckResult {
-	cfg := pool_config{lowerLimit: defaultLowerLimit}
-
-	for _, opt := range opts {
-		opt(&cfg)
-	}
-
-	check := checkMySQLPoolAvailability(pool)
-
-	return func(ctx context.Context) healing.CheckResult {
-		return CheckHelper(func() error {
-			var stats client.ConnectionStats
-			pool.GetStats(&stats)
-
-			return poolCheck(ctx, stats.IdleCount, maxAlive, cfg.lowerLimit, check)
-		})
-	}
+func MySQLReadinessProber(pool *client.Pool, maxAlive int, opts ...PoolOptions) healing.CheckerFunc {
+	// NOTE: mysql pool doesn't collect statistics of waits,
+	// so saturation of pool relies only on number of connections in use.
+	return PoolReadinessProber(func() PoolStats {
+		var stats client.ConnectionStats
+		pool.GetStats(&stats)
+
+		return PoolStats{
+			Idle:  stats.IdleCount,
+			InUse: max(stats.TotalCount-stats.IdleCount, 0),
+			Total: stats.TotalCount,
+			Max:   maxAlive,
+		}
+	}, checkMySQLPoolAvailability(pool), opts...)
 }
 
 func checkMySQLPoolAvailability(pool *client.Pool) func(context.Context) error {
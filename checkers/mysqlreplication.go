@@ -0,0 +1,155 @@
+package checkers
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+
+	"github.com/go-mysql-org/go-mysql/client"
+	"github.com/moeryomenko/healing"
+)
+
+// NOTE: SHOW REPLICA STATUS is supported since MySQL 8.0.22,
+// older servers and MariaDB support only SHOW SLAVE STATUS.
+const (
+	showReplicaStatus = "SHOW REPLICA STATUS"
+	showSlaveStatus   = "SHOW SLAVE STATUS"
+)
+
+// MySQLReplicaProber returns readiness checker function of mysql node, which fails
+// if replication lag exceeds limit, replication thread has stopped or node is replica
+// while it's expected to be primary. Replication lag is reported in details of check result.
+func MySQLReplicaProber(pool *client.Pool, opts ...ReplicationOptions) healing.CheckerFunc {
+	return mysqlReplicaProber(func(ctx context.Context, query string) (map[string]string, error) {
+		conn, err := pool.GetConn(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { pool.PutConn(conn) }()
+
+		res, err := conn.Execute(query)
+		if err != nil {
+			return nil, err
+		}
+		defer res.Close()
+
+		if res.Resultset == nil || res.RowNumber() == 0 {
+			return nil, nil
+		}
+
+		row := make(map[string]string, len(res.Fields))
+		for i, field := range res.Fields {
+			if null, _ := res.IsNull(0, i); null {
+				continue
+			}
+			value, err := res.GetString(0, i)
+			if err != nil {
+				return nil, err
+			}
+			row[string(field.Name)] = value
+		}
+		return row, nil
+	}, opts...)
+}
+
+// SQLMySQLReplicaProber returns readiness checker function of mysql node for sql.DB,
+// for more details see MySQLReplicaProber.
+func SQLMySQLReplicaProber(db *sql.DB, opts ...ReplicationOptions) healing.CheckerFunc {
+	return mysqlReplicaProber(func(ctx context.Context, query string) (map[string]string, error) {
+		rows, err := db.QueryContext(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+
+		columns, err := rows.Columns()
+		if err != nil {
+			return nil, err
+		}
+		if !rows.Next() {
+			return nil, rows.Err()
+		}
+
+		values := make([]sql.RawBytes, len(columns))
+		dest := make([]any, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		row := make(map[string]string, len(columns))
+		for i, column := range columns {
+			// NOTE: NULL values are omitted.
+			if values[i] != nil {
+				row[column] = string(values[i])
+			}
+		}
+		return row, rows.Err()
+	}, opts...)
+}
+
+// mysqlReplicaProber returns checker function, which reads replica status by given query function,
+// query returns first row of result by column names or nil row if node isn't replica.
+func mysqlReplicaProber(query func(context.Context, string) (map[string]string, error), opts ...ReplicationOptions) healing.CheckerFunc {
+	cfg := newReplicationConfig(opts...)
+
+	return func(ctx context.Context) healing.CheckResult {
+		row, err := query(ctx, showReplicaStatus)
+		if err != nil {
+			// NOTE: fallback to legacy statement, original error is reported if it fails too.
+			var legacyErr error
+			row, legacyErr = query(ctx, showSlaveStatus)
+			if legacyErr != nil {
+				return healing.CheckResult{Error: err, Status: healing.DOWN}
+			}
+		}
+
+		details := make(map[string]any)
+		res := CheckHelper(func() error { return mysqlReplicationState(row).check(cfg, details) })
+		res.Details = details
+		return res
+	}
+}
+
+// mysqlReplicationState parses row of replica status, column names
+// of both SHOW REPLICA STATUS and SHOW SLAVE STATUS are supported.
+func mysqlReplicationState(row map[string]string) replicationState {
+	if row == nil {
+		return replicationState{}
+	}
+
+	state := replicationState{replica: true}
+
+	if lag, ok := firstColumn(row, "Seconds_Behind_Source", "Seconds_Behind_Master"); ok {
+		if seconds, err := strconv.ParseInt(lag, 10, 64); err == nil {
+			d := time.Duration(seconds) * time.Second
+			state.lag = &d
+		}
+	}
+
+	threads := []struct{ name, column, legacy string }{
+		{name: "io", column: "Replica_IO_Running", legacy: "Slave_IO_Running"},
+		{name: "sql", column: "Replica_SQL_Running", legacy: "Slave_SQL_Running"},
+	}
+	for _, thread := range threads {
+		// NOTE: IO thread could be "Connecting", it isn't running yet.
+		if running, _ := firstColumn(row, thread.column, thread.legacy); running != "Yes" {
+			state.stopped = append(state.stopped, thread.name)
+		}
+	}
+
+	return state
+}
+
+// firstColumn returns value of first present column.
+func firstColumn(row map[string]string, names ...string) (string, bool) {
+	for _, name := range names {
+		if value, ok := row[name]; ok {
+			return value, true
+		}
+	}
+	return "", false
+}
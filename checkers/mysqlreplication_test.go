@@ -0,0 +1,100 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/moeryomenko/healing"
+)
+
+func TestMySQLReplicationState(t *testing.T) {
+	testcases := []struct {
+		name    string
+		row     map[string]string
+		replica bool
+		lag     string
+		stopped []string
+	}{
+		{
+			name: "primary",
+		},
+		{
+			name: "running replica",
+			row: map[string]string{
+				"Replica_IO_Running":    "Yes",
+				"Replica_SQL_Running":   "Yes",
+				"Seconds_Behind_Source": "3",
+			},
+			replica: true,
+			lag:     "3s",
+		},
+		{
+			name: "legacy columns",
+			row: map[string]string{
+				"Slave_IO_Running":      "Connecting",
+				"Slave_SQL_Running":     "Yes",
+				"Seconds_Behind_Master": "0",
+			},
+			replica: true,
+			lag:     "0s",
+			stopped: []string{"io"},
+		},
+		{
+			name: "stopped sql thread",
+			row: map[string]string{
+				"Replica_IO_Running":  "Yes",
+				"Replica_SQL_Running": "No",
+			},
+			replica: true,
+			stopped: []string{"sql"},
+		},
+	}
+	for _, testcase := range testcases {
+		tc := testcase
+		t.Run(tc.name, func(t *testing.T) {
+			state := mysqlReplicationState(tc.row)
+			assert.Equal(t, tc.replica, state.replica)
+			assert.Equal(t, tc.stopped, state.stopped)
+			if tc.lag == "" {
+				assert.Nil(t, state.lag)
+			} else {
+				require.NotNil(t, state.lag)
+				assert.Equal(t, tc.lag, state.lag.String())
+			}
+		})
+	}
+}
+
+func TestMySQLReplicaProber(t *testing.T) {
+	replica := map[string]string{
+		"Slave_IO_Running":      "Yes",
+		"Slave_SQL_Running":     "Yes",
+		"Seconds_Behind_Master": "10",
+	}
+	// NOTE: emulate server, which doesn't support SHOW REPLICA STATUS.
+	query := func(_ context.Context, query string) (map[string]string, error) {
+		if query == showReplicaStatus {
+			return nil, errors.New("syntax error")
+		}
+		return replica, nil
+	}
+
+	res := mysqlReplicaProber(query)(context.Background())
+	assert.Equal(t, healing.UP, res.Status)
+	assert.Equal(t, "10s", res.Details["replication_lag"])
+
+	res = mysqlReplicaProber(query, WithMaxReplicationLag(5*time.Second))(context.Background())
+	assert.Equal(t, healing.DOWN, res.Status)
+	assert.ErrorIs(t, res.Error, ErrReplicationLag)
+
+	replica["Slave_SQL_Running"] = "No"
+	res = mysqlReplicaProber(query)(context.Background())
+	assert.Equal(t, healing.DOWN, res.Status)
+	assert.ErrorIs(t, res.Error, ErrReplicationStopped)
+	assert.Equal(t, []string{"sql"}, res.Details["stopped_threads"])
+}
@@ -0,0 +1,110 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/moeryomenko/healing"
+	"github.com/nats-io/nats.go"
+)
+
+var (
+	// ErrNATSNotConnected indicates that nats connection isn't connected to server.
+	ErrNATSNotConnected = errors.New("nats connection is not connected")
+	// ErrNATSSlowRTT indicates that round trip to nats server exceeds limit.
+	ErrNATSSlowRTT = errors.New("nats round trip time exceeds limit")
+	// ErrNATSPending indicates that JetStream consumer has too many pending messages.
+	ErrNATSPending = errors.New("nats consumer pending messages exceed limit")
+)
+
+// NATSProbes returns liveness and readiness probes for nats connection. Liveness fails
+// only after connection is closed, while reconnecting connection is still alive.
+// Readiness requires connection to be connected, see WithNATSFlush, WithJetStreamStream
+// and WithJetStreamConsumer for optional checks.
+func NATSProbes(conn *nats.Conn, opts ...NATSOptions) (
+	liveness, readiness healing.CheckerFunc,
+) {
+	return func(ctx context.Context) healing.CheckResult {
+		return CheckHelper(func() error { return natsLiveness(conn.Status(), conn.LastError()) })
+	}, NATSReadinessProber(conn, opts...)
+}
+
+// NATSReadinessProber returns nats connection readiness checker function,
+// for more details see NATSProbes.
+func NATSReadinessProber(conn *nats.Conn, opts ...NATSOptions) healing.CheckerFunc {
+	cfg := newNATSConfig(opts...)
+
+	return func(ctx context.Context) healing.CheckResult {
+		details := make(map[string]any)
+		res := CheckHelper(func() error {
+			status := conn.Status()
+			details["status"] = status.String()
+			if status != nats.CONNECTED {
+				return fmt.Errorf("%w: %s", ErrNATSNotConnected, status)
+			}
+
+			if cfg.flush {
+				start := time.Now()
+				if err := conn.FlushWithContext(ctx); err != nil {
+					return err
+				}
+				rtt := time.Since(start)
+				details["rtt"] = rtt.String()
+				if cfg.maxRTT > 0 && rtt > cfg.maxRTT {
+					return fmt.Errorf("%w: %s > %s", ErrNATSSlowRTT, rtt, cfg.maxRTT)
+				}
+			}
+
+			if cfg.stream == "" {
+				return nil
+			}
+			js, err := conn.JetStream()
+			if err != nil {
+				return err
+			}
+			return checkJetStream(ctx, js, cfg, details)
+		})
+		res.Details = details
+		return res
+	}
+}
+
+func natsLiveness(status nats.Status, lastErr error) error {
+	if status != nats.CLOSED {
+		return nil
+	}
+	if lastErr != nil {
+		return fmt.Errorf("%w: %w", ErrNATSNotConnected, lastErr)
+	}
+	return fmt.Errorf("%w: %s", ErrNATSNotConnected, status)
+}
+
+func checkJetStream(ctx context.Context, js nats.JetStreamContext, cfg nats_config, details map[string]any) error {
+	if cfg.consumer == "" {
+		info, err := js.StreamInfo(cfg.stream, nats.Context(ctx))
+		if err != nil {
+			return err
+		}
+		details["stream_messages"] = info.State.Msgs
+		return nil
+	}
+
+	info, err := js.ConsumerInfo(cfg.stream, cfg.consumer, nats.Context(ctx))
+	if err != nil {
+		return err
+	}
+	return checkConsumerPending(info, cfg.maxPending, details)
+}
+
+// checkConsumerPending checks number of pending messages of consumer and fills details of check result.
+func checkConsumerPending(info *nats.ConsumerInfo, maxPending uint64, details map[string]any) error {
+	details["num_pending"] = info.NumPending
+	details["num_ack_pending"] = info.NumAckPending
+
+	if maxPending > 0 && info.NumPending > maxPending {
+		return fmt.Errorf("%w: %d > %d", ErrNATSPending, info.NumPending, maxPending)
+	}
+	return nil
+}
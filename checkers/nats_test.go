@@ -0,0 +1,34 @@
+package checkers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/nats-io/nats.go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNATSLiveness(t *testing.T) {
+	for _, status := range []nats.Status{nats.CONNECTED, nats.CONNECTING, nats.RECONNECTING, nats.DRAINING_SUBS} {
+		assert.NoError(t, natsLiveness(status, nil), status.String())
+	}
+
+	assert.ErrorIs(t, natsLiveness(nats.CLOSED, nil), ErrNATSNotConnected)
+
+	lastErr := errors.New("authorization violation")
+	err := natsLiveness(nats.CLOSED, lastErr)
+	assert.ErrorIs(t, err, ErrNATSNotConnected)
+	assert.ErrorIs(t, err, lastErr)
+}
+
+func TestCheckConsumerPending(t *testing.T) {
+	info := &nats.ConsumerInfo{NumPending: 10, NumAckPending: 2}
+
+	details := map[string]any{}
+	assert.NoError(t, checkConsumerPending(info, 0, details))
+	assert.Equal(t, uint64(10), details["num_pending"])
+	assert.Equal(t, 2, details["num_ack_pending"])
+
+	assert.NoError(t, checkConsumerPending(info, 10, details))
+	assert.ErrorIs(t, checkConsumerPending(info, 9, details), ErrNATSPending)
+}
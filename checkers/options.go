@@ -1,31 +1,455 @@
 package checkers
 
-import "time"
+import (
+	"crypto/tls"
+	"net/http"
+	"regexp"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+)
 
 type PoolOptions func(*pool_config)
 
-// WithLowerLimitPool sets the lower limit of free connections
-// during which a real ping of the base is performed.
+// WithLowerLimitPool sets the lower limit of percent of free connections,
+// pool is considered ready only if percent of free connections is above limit.
 func WithLowerLimitPool(lower uint) PoolOptions {
 	return func(p *pool_config) {
 		p.lowerLimit = int(lower)
 	}
 }
 
-// WithPoolLivenessPeriod sets period between real ping requests to database.
+// WithMinFreeConns sets the lower limit of number of free connections,
+// pool is considered ready only if it has at least given number of free connections.
+func WithMinFreeConns(free uint) PoolOptions {
+	return func(p *pool_config) {
+		p.minFree = int(free)
+	}
+}
+
+// WithPoolLivenessPeriod sets period between real ping requests to database,
+// liveness checks between pings report result of last ping. By default pool is pinged
+// on every check, see PoolLivenessProber.
 func WithPoolLivenessPeriod(period time.Duration) PoolOptions {
 	return func(p *pool_config) {
 		p.livenessPeriod = period
 	}
 }
 
-const (
-	defaultLowerLimit         = 5
-	defaultPoolLivenessPeriod = 5 * time.Second
-)
+// WithPassiveReadiness enables passive readiness check, which doesn't acquire
+// connection from pool and decides readiness only by pool statistics.
+func WithPassiveReadiness() PoolOptions {
+	return func(p *pool_config) {
+		p.passive = true
+	}
+}
+
+// WithMaxAcquireWait sets upper limit of average time of waiting for connection
+// since previous check. By default wait time isn't limited.
+func WithMaxAcquireWait(wait time.Duration) PoolOptions {
+	return func(p *pool_config) {
+		p.maxAcquireWait = wait
+	}
+}
+
+// WithMaxAcquireWaits sets upper limit of number of acquires, which had to wait
+// for connection per check interval, i.e. since previous check.
+// By default number of waits isn't limited.
+func WithMaxAcquireWaits(waits uint) PoolOptions {
+	return func(p *pool_config) {
+		p.maxAcquireWaits = int64(waits)
+	}
+}
+
+// WithMaxPendingAcquires sets upper limit of number of acquires currently waiting
+// for connection, it's checked only if pool exposes it, see PoolStats.Pending.
+// By default number of pending acquires isn't limited.
+func WithMaxPendingAcquires(pending uint) PoolOptions {
+	return func(p *pool_config) {
+		p.maxPending = int64(pending)
+	}
+}
+
+const defaultLowerLimit = 5
+
+func newPoolConfig(opts ...PoolOptions) pool_config {
+	cfg := pool_config{
+		lowerLimit: defaultLowerLimit,
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
 
 type pool_config struct {
 	lowerLimit int
+	minFree    int
 
 	livenessPeriod time.Duration
+
+	passive         bool
+	maxAcquireWait  time.Duration
+	maxAcquireWaits int64
+	maxPending      int64
+}
+
+type ReplicationOptions func(*replication_config)
+
+// WithMaxReplicationLag sets upper limit of replication lag of replica,
+// by default lag isn't limited.
+func WithMaxReplicationLag(lag time.Duration) ReplicationOptions {
+	return func(r *replication_config) {
+		r.maxLag = lag
+	}
+}
+
+// WithExpectPrimary marks node as expected primary, node is considered ready
+// only if it isn't replica.
+func WithExpectPrimary() ReplicationOptions {
+	return func(r *replication_config) {
+		r.expectPrimary = true
+	}
+}
+
+func newReplicationConfig(opts ...ReplicationOptions) replication_config {
+	var cfg replication_config
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+type replication_config struct {
+	maxLag        time.Duration
+	expectPrimary bool
+}
+
+type QueryOptions func(*query_config)
+
+// WithQueryTimeout sets own timeout of query execution,
+// by default only timeout of check is applied.
+func WithQueryTimeout(timeout time.Duration) QueryOptions {
+	return func(q *query_config) {
+		q.timeout = timeout
+	}
+}
+
+func newQueryConfig(opts ...QueryOptions) query_config {
+	var cfg query_config
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+type query_config struct {
+	timeout time.Duration
+}
+
+type MigrationOptions func(*migration_config)
+
+// WithMigrationTable sets name of migrations bookkeeping table,
+// by default table of migration tool is used.
+func WithMigrationTable(table string) MigrationOptions {
+	return func(m *migration_config) {
+		m.table = table
+	}
+}
+
+// WithMigrationQueryTimeout sets own timeout of query of migration version.
+func WithMigrationQueryTimeout(timeout time.Duration) MigrationOptions {
+	return func(m *migration_config) {
+		m.timeout = timeout
+	}
+}
+
+func newMigrationConfig(table string, opts ...MigrationOptions) migration_config {
+	cfg := migration_config{table: table}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+type migration_config struct {
+	table   string
+	timeout time.Duration
+}
+
+type RedisInfoOptions func(*redis_info_config)
+
+// WithMaxReplicationOffsetLag sets upper limit of difference between replication offsets
+// of master and its replicas in bytes, by default lag isn't limited.
+func WithMaxReplicationOffsetLag(lag uint) RedisInfoOptions {
+	return func(r *redis_info_config) {
+		r.maxOffsetLag = int64(lag)
+	}
+}
+
+func newRedisInfoConfig(opts ...RedisInfoOptions) redis_info_config {
+	var cfg redis_info_config
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+type redis_info_config struct {
+	maxOffsetLag int64
+}
+
+type AMQPOptions func(*amqp_config)
+
+// WithAMQPQueue enables readiness check of queue by given name,
+// which is declared passively on own channel.
+func WithAMQPQueue(name string) AMQPOptions {
+	return func(a *amqp_config) {
+		a.queue = name
+	}
+}
+
+// WithMaxQueueMessages sets upper limit of number of messages in queue,
+// by default depth of queue isn't limited.
+func WithMaxQueueMessages(messages uint) AMQPOptions {
+	return func(a *amqp_config) {
+		a.maxMessages = int(messages)
+	}
+}
+
+// WithMinQueueConsumers sets lower limit of number of consumers of queue.
+func WithMinQueueConsumers(consumers uint) AMQPOptions {
+	return func(a *amqp_config) {
+		a.minConsumers = int(consumers)
+	}
+}
+
+func newAMQPConfig(opts ...AMQPOptions) amqp_config {
+	var cfg amqp_config
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+type amqp_config struct {
+	queue        string
+	maxMessages  int
+	minConsumers int
+}
+
+type KafkaOptions func(*kafka_config)
+
+// WithKafkaTopic requires topic to exist with given number of partitions,
+// zero partitions means any number of partitions.
+func WithKafkaTopic(topic string, partitions uint) KafkaOptions {
+	return func(k *kafka_config) {
+		k.topics[topic] = int(partitions)
+	}
+}
+
+// WithKafkaConsumerLag sets upper limit of total lag of consumer group.
+func WithKafkaConsumerLag(group string, maxLag uint) KafkaOptions {
+	return func(k *kafka_config) {
+		k.groups[group] = int64(maxLag)
+	}
+}
+
+func newKafkaConfig(opts ...KafkaOptions) kafka_config {
+	cfg := kafka_config{
+		topics: make(map[string]int),
+		groups: make(map[string]int64),
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+type kafka_config struct {
+	topics map[string]int
+	groups map[string]int64
+}
+
+type NATSOptions func(*nats_config)
+
+// WithNATSFlush enables round trip to server during readiness check,
+// connection is considered ready only if round trip is faster than given limit.
+// Zero limit means any round trip time.
+func WithNATSFlush(maxRTT time.Duration) NATSOptions {
+	return func(n *nats_config) {
+		n.flush = true
+		n.maxRTT = maxRTT
+	}
+}
+
+// WithJetStreamStream requires JetStream stream to exist.
+func WithJetStreamStream(stream string) NATSOptions {
+	return func(n *nats_config) {
+		n.stream = stream
+	}
+}
+
+// WithJetStreamConsumer requires JetStream consumer of stream to exist with number
+// of pending messages not above given limit. Zero limit means any number of pending messages.
+func WithJetStreamConsumer(stream, consumer string, maxPending uint) NATSOptions {
+	return func(n *nats_config) {
+		n.stream = stream
+		n.consumer = consumer
+		n.maxPending = uint64(maxPending)
+	}
+}
+
+func newNATSConfig(opts ...NATSOptions) nats_config {
+	var cfg nats_config
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+type nats_config struct {
+	flush  bool
+	maxRTT time.Duration
+
+	stream     string
+	consumer   string
+	maxPending uint64
+}
+
+type MongoOptions func(*mongo_config)
+
+// WithMongoReadPref sets read preference of liveness ping, by default primary is pinged.
+func WithMongoReadPref(rp *readpref.ReadPref) MongoOptions {
+	return func(m *mongo_config) {
+		m.readPref = rp
+	}
+}
+
+// WithMongoPoolMonitor enables readiness check of connection checkout wait
+// collected by given monitor, limits are set by WithMaxAcquireWait and WithMaxAcquireWaits.
+func WithMongoPoolMonitor(monitor *MongoPoolMonitor, opts ...PoolOptions) MongoOptions {
+	return func(m *mongo_config) {
+		m.monitor = monitor
+		m.pool = newPoolConfig(opts...)
+	}
+}
+
+func newMongoConfig(opts ...MongoOptions) mongo_config {
+	cfg := mongo_config{
+		readPref: readpref.Primary(),
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+type mongo_config struct {
+	readPref *readpref.ReadPref
+
+	monitor *MongoPoolMonitor
+	pool    pool_config
+}
+
+type HTTPOptions func(*http_config)
+
+// WithHTTPMethod sets method of request, by default GET is used.
+func WithHTTPMethod(method string) HTTPOptions {
+	return func(h *http_config) {
+		h.method = method
+	}
+}
+
+// WithHTTPStatus sets expected status codes of response, by default any 2xx code is expected.
+func WithHTTPStatus(codes ...int) HTTPOptions {
+	return func(h *http_config) {
+		h.codes = append(h.codes, codes...)
+	}
+}
+
+// WithHTTPBodyContains requires body of response to contain given substring.
+func WithHTTPBodyContains(substr string) HTTPOptions {
+	return func(h *http_config) {
+		h.matchers = append(h.matchers, bodyContains(substr))
+	}
+}
+
+// WithHTTPBodyRegexp requires body of response to match given regular expression.
+func WithHTTPBodyRegexp(re *regexp.Regexp) HTTPOptions {
+	return func(h *http_config) {
+		h.matchers = append(h.matchers, bodyRegexp(re))
+	}
+}
+
+// WithHTTPBodyJSONPath requires JSON body of response to have value at given path
+// equal to expected in string representation, e.g. `$.status` equal to `UP`.
+// Path supports fields and array indexes, like `$.checks[0].status`.
+func WithHTTPBodyJSONPath(path, expected string) HTTPOptions {
+	return func(h *http_config) {
+		h.matchers = append(h.matchers, bodyJSONPath(path, expected))
+	}
+}
+
+// WithHTTPHeader adds header to request.
+func WithHTTPHeader(key, value string) HTTPOptions {
+	return func(h *http_config) {
+		h.header.Add(key, value)
+	}
+}
+
+// WithHTTPTLSConfig sets TLS configuration of client.
+func WithHTTPTLSConfig(tlsConfig *tls.Config) HTTPOptions {
+	return func(h *http_config) {
+		h.tlsConfig = tlsConfig
+	}
+}
+
+// WithHTTPTimeout sets own timeout of request,
+// by default only timeout of check is applied.
+func WithHTTPTimeout(timeout time.Duration) HTTPOptions {
+	return func(h *http_config) {
+		h.timeout = timeout
+	}
+}
+
+func newHTTPConfig(opts ...HTTPOptions) http_config {
+	cfg := http_config{
+		method: http.MethodGet,
+		header: make(http.Header),
+	}
+
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+
+	return cfg
+}
+
+type http_config struct {
+	method    string
+	header    http.Header
+	tlsConfig *tls.Config
+	timeout   time.Duration
+
+	codes    []int
+	matchers []func([]byte) error
 }
@@ -0,0 +1,77 @@
+package checkers
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/moeryomenko/healing"
+)
+
+// pgReplicationQuery selects recovery state, replay lag, whether all received WAL
+// is replayed, pid and status of WAL receiver, which are NULL if receiver isn't running.
+// NOTE: for roles without pg_read_all_stats all columns of pg_stat_wal_receiver
+// except pid are NULL.
+const pgReplicationQuery = `SELECT pg_is_in_recovery(),
+	EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())::float8,
+	pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn(),
+	(SELECT pid FROM pg_stat_wal_receiver),
+	(SELECT status FROM pg_stat_wal_receiver)`
+
+// PgReplicationProber returns readiness checker function of postgresql node, which
+// fails if node is in recovery while it's expected to be primary, WAL receiver of replica
+// isn't running or streaming, or replay lag of replica exceeds limit. Replay lag is reported
+// in details of check result. Status of WAL receiver is visible only to roles with
+// pg_read_all_stats, for other roles running receiver is considered streaming.
+func PgReplicationProber(pool *pgxpool.Pool, opts ...ReplicationOptions) healing.CheckerFunc {
+	cfg := newReplicationConfig(opts...)
+
+	return func(ctx context.Context) healing.CheckResult {
+		state, err := scanPgReplication(pool.QueryRow(ctx, pgReplicationQuery))
+		if err != nil {
+			return healing.CheckResult{Error: err, Status: healing.DOWN}
+		}
+
+		details := make(map[string]any)
+		res := CheckHelper(func() error { return state.check(cfg, details) })
+		res.Details = details
+		return res
+	}
+}
+
+// scanPgReplication scans result of pgReplicationQuery to replication state.
+func scanPgReplication(row pgx.Row) (replicationState, error) {
+	var (
+		state    replicationState
+		lag      *float64
+		caughtUp *bool
+		pid      *int32
+		status   *string
+	)
+	if err := row.Scan(&state.replica, &lag, &caughtUp, &pid, &status); err != nil {
+		return replicationState{}, err
+	}
+	if !state.replica {
+		return state, nil
+	}
+
+	// NOTE: receiver is running if view has row, its status is unknown without privileges.
+	streaming := pid != nil && (status == nil || *status == "streaming")
+	if !streaming {
+		// NOTE: replica cut off from primary replays nothing new, so it can't be trusted.
+		state.stopped = append(state.stopped, "wal_receiver")
+	}
+
+	switch {
+	case streaming && caughtUp != nil && *caughtUp:
+		// NOTE: replay timestamp isn't changed while primary has no writes,
+		// so lag of streaming replica, which has replayed all received WAL, is zero.
+		state.lag = new(time.Duration)
+	case lag != nil:
+		d := time.Duration(*lag * float64(time.Second))
+		state.lag = &d
+	}
+
+	return state, nil
+}
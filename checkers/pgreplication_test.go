@@ -0,0 +1,148 @@
+package checkers
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pgRow is result of pgReplicationQuery.
+type pgRow []any
+
+func (r pgRow) Scan(dest ...any) error {
+	if len(dest) != len(r) {
+		return errors.New("unexpected number of columns")
+	}
+	for i, value := range r {
+		switch d := dest[i].(type) {
+		case *bool:
+			*d = value.(bool)
+		case **float64:
+			if value != nil {
+				v := value.(float64)
+				*d = &v
+			}
+		case **int32:
+			if value != nil {
+				v := value.(int32)
+				*d = &v
+			}
+		case **bool:
+			if value != nil {
+				v := value.(bool)
+				*d = &v
+			}
+		case **string:
+			if value != nil {
+				v := value.(string)
+				*d = &v
+			}
+		default:
+			return errors.New("unexpected destination")
+		}
+	}
+	return nil
+}
+
+func TestScanPgReplication(t *testing.T) {
+	testcases := []struct {
+		name    string
+		row     pgRow
+		opts    []ReplicationOptions
+		lag     string
+		stopped []string
+		err     error
+	}{
+		{
+			name: "primary",
+			row:  pgRow{false, nil, nil, nil, nil},
+			opts: []ReplicationOptions{WithExpectPrimary(), WithMaxReplicationLag(time.Second)},
+		},
+		{
+			name: "streaming replica",
+			row:  pgRow{true, 0.5, false, int32(42), "streaming"},
+			opts: []ReplicationOptions{WithMaxReplicationLag(time.Second)},
+			lag:  "500ms",
+		},
+		{
+			name: "idle streaming replica",
+			row:  pgRow{true, 3600., true, int32(42), "streaming"},
+			opts: []ReplicationOptions{WithMaxReplicationLag(time.Second)},
+			lag:  "0s",
+		},
+		{
+			name: "lagging replica",
+			row:  pgRow{true, 10., false, int32(42), "streaming"},
+			opts: []ReplicationOptions{WithMaxReplicationLag(time.Second)},
+			lag:  "10s",
+			err:  ErrReplicationLag,
+		},
+		{
+			// NOTE: status of receiver is NULL for roles without pg_read_all_stats.
+			name: "replica without privileges",
+			row:  pgRow{true, 0.5, false, int32(42), nil},
+			opts: []ReplicationOptions{WithMaxReplicationLag(time.Second)},
+			lag:  "500ms",
+		},
+		{
+			name: "idle replica without privileges",
+			row:  pgRow{true, 3600., true, int32(42), nil},
+			opts: []ReplicationOptions{WithMaxReplicationLag(time.Second)},
+			lag:  "0s",
+		},
+		{
+			name:    "disconnected replica",
+			row:     pgRow{true, 0.5, true, nil, nil},
+			lag:     "500ms",
+			stopped: []string{"wal_receiver"},
+			err:     ErrReplicationStopped,
+		},
+		{
+			name:    "reconnecting replica",
+			row:     pgRow{true, nil, nil, int32(42), "waiting"},
+			stopped: []string{"wal_receiver"},
+			err:     ErrReplicationStopped,
+		},
+		{
+			name: "replica instead of primary",
+			row:  pgRow{true, 0., true, int32(42), "streaming"},
+			opts: []ReplicationOptions{WithExpectPrimary()},
+			lag:  "0s",
+			err:  ErrNotPrimary,
+		},
+	}
+	for _, testcase := range testcases {
+		tc := testcase
+		t.Run(tc.name, func(t *testing.T) {
+			state, err := scanPgReplication(tc.row)
+			require.NoError(t, err)
+			assert.Equal(t, tc.stopped, state.stopped)
+			if tc.lag == "" {
+				assert.Nil(t, state.lag)
+			} else {
+				require.NotNil(t, state.lag)
+				assert.Equal(t, tc.lag, state.lag.String())
+			}
+
+			err = state.check(newReplicationConfig(tc.opts...), map[string]any{})
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestPgReplicationQuery(t *testing.T) {
+	// NOTE: lag is based on replay timestamp and receiver status is checked.
+	assert.Contains(t, pgReplicationQuery, "now() - pg_last_xact_replay_timestamp()")
+	assert.Contains(t, pgReplicationQuery, "SELECT pid FROM pg_stat_wal_receiver")
+	assert.Contains(t, pgReplicationQuery, "SELECT status FROM pg_stat_wal_receiver")
+
+	_, err := scanPgReplication(pgRow{true})
+	assert.Error(t, err)
+}
@@ -2,6 +2,7 @@ package checkers
 
 import (
 	"context"
+	"time"
 
 	"github.com/jackc/pgx/v4/pgxpool"
 	"github.com/moeryomenko/healing"
@@ -9,27 +10,13 @@ import (
 
 // PgxProbes returns liveness and readiness probes for pgxpool.Pool.
 func PgxProbes(pool *pgxpool.Pool, opts ...PoolOptions) (
-	liveness, readiness func(context.Context) healing.CheckResult,
+	liveness, readiness healing.CheckerFunc,
 ) {
-	cfg := pool_config{lowerLimit: defaultLowerLimit, livenessPeriod: defaultPoolLivenessPeriod}
-
-	for _, opt := range opts {
-		opt(&cfg)
-	}
-
-	return func(ctx context.Context) healing.CheckResult {
-		return CheckHelper(checkPoolLiveness(ctx, cfg.livenessPeriod, pool.Ping))
-	}, PgxReadinessProber(pool, opts...)
+	return PoolLivenessProber(pool.Ping, opts...), PgxReadinessProber(pool, opts...)
 }
 
 // PgxReadinessProber returns pg conn pool readiness checker function.
-func PgxReadinessProber(pool *pgxpool.Pool, opts ...PoolOptions) func(context.Context) healing.CheckResult {
-	cfg := pool_config{lowerLimit: defaultLowerLimit}
-
-	for _, opt := range opts {
-		opt(&cfg)
-	}
-
+func PgxReadinessProber(pool *pgxpool.Pool, opts ...PoolOptions) healing.CheckerFunc {
 	// NOTE: only check we can aquire connection from pool w/o real execution ping command.
 	check := func(ctx context.Context) error {
 		conn, err := pool.Acquire(ctx)
@@ -40,11 +27,34 @@ func PgxReadinessProber(pool *pgxpool.Pool, opts ...PoolOptions) func(context.Co
 		return nil
 	}
 
-	return func(ctx context.Context) healing.CheckResult {
-		return CheckHelper(func() error {
-			stats := pool.Stat()
+	return PoolReadinessProber(func() PoolStats {
+		return pgxPoolStats(pool.Stat())
+	}, check, opts...)
+}
+
+// pgxStat is statistics of pgxpool.Pool.
+type pgxStat interface {
+	IdleConns() int32
+	AcquiredConns() int32
+	TotalConns() int32
+	MaxConns() int32
+	AcquireCount() int64
+	EmptyAcquireCount() int64
+	AcquireDuration() time.Duration
+}
 
-			return poolCheck(ctx, int(stats.IdleConns()), int(pool.Config().MaxConns), cfg.lowerLimit, check)
-		})
+func pgxPoolStats(stats pgxStat) PoolStats {
+	return PoolStats{
+		Idle:  int(stats.IdleConns()),
+		InUse: int(stats.AcquiredConns()),
+		Total: int(stats.TotalConns()),
+		Max:   int(stats.MaxConns()),
+		// NOTE: empty acquires are acquires, which had to wait for connection,
+		// but duration is measured over all successful acquires.
+		Acquire: &AcquireStats{
+			Waits:    stats.EmptyAcquireCount(),
+			WaitTime: stats.AcquireDuration(),
+			Acquires: stats.AcquireCount(),
+		},
 	}
 }
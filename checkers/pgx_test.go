@@ -108,3 +108,45 @@ func startPgIdleXact(ctx context.Context, pool *pgxpool.Pool) {
 		return nil
 	})
 }
+
+// pgxFakeStat is statistics of pgxpool.Pool.
+type pgxFakeStat struct {
+	idle, acquired, total, max int32
+	acquires, emptyAcquires    int64
+	acquireDuration            time.Duration
+}
+
+func (s pgxFakeStat) IdleConns() int32               { return s.idle }
+func (s pgxFakeStat) AcquiredConns() int32           { return s.acquired }
+func (s pgxFakeStat) TotalConns() int32              { return s.total }
+func (s pgxFakeStat) MaxConns() int32                { return s.max }
+func (s pgxFakeStat) AcquireCount() int64            { return s.acquires }
+func (s pgxFakeStat) EmptyAcquireCount() int64       { return s.emptyAcquires }
+func (s pgxFakeStat) AcquireDuration() time.Duration { return s.acquireDuration }
+
+func TestPgxPoolStats(t *testing.T) {
+	stat := pgxFakeStat{idle: 8, acquired: 2, total: 10, max: 10}
+	readiness := PoolReadinessProber(func() PoolStats { return pgxPoolStats(stat) }, nil,
+		WithPassiveReadiness(), WithMaxAcquireWait(10*time.Millisecond))
+
+	// NOTE: many fast acquires and one empty acquire during round.
+	stat.acquires = 10_001
+	stat.emptyAcquires = 1
+	stat.acquireDuration = 100*time.Millisecond + 10*time.Microsecond
+
+	res := readiness(context.Background())
+	assert.Equal(t, healing.UP, res.Status, res.Error)
+	assert.Equal(t, int64(1), res.Details["acquire_waits"])
+	assert.Equal(t, "10µs", res.Details["acquire_wait"])
+	assert.Equal(t, 2, res.Details["in_use_conns"])
+
+	// NOTE: slow acquires make average wait exceed limit.
+	stat.acquires += 10
+	stat.emptyAcquires += 10
+	stat.acquireDuration += time.Second
+
+	res = readiness(context.Background())
+	assert.Equal(t, healing.DOWN, res.Status)
+	assert.ErrorIs(t, res.Error, ErrPoolNotReady)
+	assert.Equal(t, "100ms", res.Details["acquire_wait"])
+}
@@ -0,0 +1,78 @@
+// Package pgxv5 contains checkers for pgx v5 connection pool,
+// it's separated from checkers package so users of pgx v4 don't depend on pgx v5.
+package pgxv5
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/moeryomenko/healing"
+
+	"github.com/moeryomenko/healing/checkers"
+)
+
+// Probes returns liveness and readiness probes for pgxpool.Pool.
+func Probes(pool *pgxpool.Pool, opts ...checkers.PoolOptions) (
+	liveness, readiness healing.CheckerFunc,
+) {
+	return checkers.PoolLivenessProber(pool.Ping, opts...), ReadinessProber(pool, opts...)
+}
+
+// ReadinessProber returns pg conn pool readiness checker function.
+func ReadinessProber(pool *pgxpool.Pool, opts ...checkers.PoolOptions) healing.CheckerFunc {
+	readiness := checkers.PoolReadinessProber(func() checkers.PoolStats {
+		return poolStats(pool.Stat())
+	}, acquire(pool), opts...)
+
+	return func(ctx context.Context) healing.CheckResult {
+		res := readiness(ctx)
+
+		stats := pool.Stat()
+		res.Details["acquire_count"] = stats.AcquireCount()
+		res.Details["empty_acquire_count"] = stats.EmptyAcquireCount()
+		res.Details["canceled_acquire_count"] = stats.CanceledAcquireCount()
+		res.Details["constructing_conns"] = stats.ConstructingConns()
+		return res
+	}
+}
+
+// stat is statistics of pgxpool.Pool.
+type stat interface {
+	IdleConns() int32
+	AcquiredConns() int32
+	TotalConns() int32
+	MaxConns() int32
+	AcquireCount() int64
+	EmptyAcquireCount() int64
+	AcquireDuration() time.Duration
+}
+
+func poolStats(stats stat) checkers.PoolStats {
+	return checkers.PoolStats{
+		Idle:  int(stats.IdleConns()),
+		InUse: int(stats.AcquiredConns()),
+		Total: int(stats.TotalConns()),
+		Max:   int(stats.MaxConns()),
+		// NOTE: empty acquires are acquires, which had to wait for connection,
+		// but duration is measured over all successful acquires.
+		Acquire: &checkers.AcquireStats{
+			Waits:    stats.EmptyAcquireCount(),
+			WaitTime: stats.AcquireDuration(),
+			Acquires: stats.AcquireCount(),
+		},
+	}
+}
+
+// acquire returns check, which only checks we can aquire connection
+// from pool w/o real execution ping command.
+func acquire(pool *pgxpool.Pool) func(context.Context) error {
+	return func(ctx context.Context) error {
+		conn, err := pool.Acquire(ctx)
+		if err != nil {
+			return err
+		}
+		conn.Release()
+		return nil
+	}
+}
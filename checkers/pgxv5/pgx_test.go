@@ -0,0 +1,156 @@
+package pgxv5
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/moeryomenko/healing"
+	"github.com/moeryomenko/healing/checkers"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/orlangure/gnomock"
+	"github.com/orlangure/gnomock/preset/postgres"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// db settings.
+const (
+	user     = "test"
+	password = "testpass"
+	database = "testdb"
+)
+
+func TestIntegration_PGReadiness(t *testing.T) {
+	// start postgresql.
+	pg := postgres.Preset(
+		postgres.WithUser(user, password),
+		postgres.WithDatabase(database),
+	)
+	container, err := gnomock.Start(pg)
+	require.NoError(t, err)
+	defer func() { gnomock.Stop(container) }()
+
+	// create our pg connections pool.
+	poolConfig, err := pgxpool.ParseConfig(
+		fmt.Sprintf("user=%s password=%s host=%s port=%d dbname=%s pool_max_conns=1",
+			user, password, container.Host, container.DefaultPort(), database))
+	require.NoError(t, err)
+
+	pgpool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
+	require.NoError(t, err)
+	defer pgpool.Close()
+
+	healthController := healing.New(8080)
+	healthController.AddReadyChecker("postgresql_controller", ReadinessProber(pgpool))
+
+	// run workload.
+	workloadCtx, workloadCancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer workloadCancel()
+	go RunPgLoad(workloadCtx, t, pgpool)
+	// run readiness controller.
+	healthCtx, healthCancel := context.WithTimeout(context.Background(), 4*time.Second)
+	defer healthCancel()
+	go healthController.Heartbeat(healthCtx)
+	defer func() {
+		healthController.Stop(context.Background())
+	}()
+
+	readinessTicker := time.NewTicker(time.Second)
+	defer readinessTicker.Stop()
+	loadIsStopped := false
+	for {
+		select {
+		case <-workloadCtx.Done():
+			loadIsStopped = true
+		case <-readinessTicker.C:
+			resp, err := http.Get("http://localhost:8080/ready")
+			assert.NoError(t, err)
+			if loadIsStopped {
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+			} else {
+				assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+			}
+		case <-healthCtx.Done():
+			return
+		}
+	}
+}
+
+func RunPgLoad(ctx context.Context, t *testing.T, pool *pgxpool.Pool) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		default:
+			for i := 0; i < 100; i++ {
+				go func() { startPgIdleXact(ctx, pool) }()
+			}
+		}
+	}
+}
+
+// startPgIdleXact starts transaction and goes sleeping for specified amount of time.
+func startPgIdleXact(ctx context.Context, pool *pgxpool.Pool) {
+	_ = pgx.BeginTxFunc(ctx, pool, pgx.TxOptions{IsoLevel: pgx.ReadCommitted}, func(tx pgx.Tx) error {
+		// Create a temp table using single row from target table. Later,
+		// transaction will be rolled back and temp table will be dropped. Also, any errors could
+		// be ignored, because in this case transaction (aborted) also stay idle.
+		temp := time.Now().Unix()
+		q := fmt.Sprintf("CREATE TEMPORARY TABLE temp_%d (c INT)", temp)
+		_, err := tx.Exec(ctx, q)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			tx.Rollback(ctx)
+		}()
+
+		// Stop execution only if context has been done.
+		<-ctx.Done()
+		return nil
+	})
+}
+
+// fakeStat is statistics of pgxpool.Pool.
+type fakeStat struct {
+	idle, acquired, total, max int32
+	acquires, emptyAcquires    int64
+	acquireDuration            time.Duration
+}
+
+func (s fakeStat) IdleConns() int32               { return s.idle }
+func (s fakeStat) AcquiredConns() int32           { return s.acquired }
+func (s fakeStat) TotalConns() int32              { return s.total }
+func (s fakeStat) MaxConns() int32                { return s.max }
+func (s fakeStat) AcquireCount() int64            { return s.acquires }
+func (s fakeStat) EmptyAcquireCount() int64       { return s.emptyAcquires }
+func (s fakeStat) AcquireDuration() time.Duration { return s.acquireDuration }
+
+func TestPoolStats(t *testing.T) {
+	stat := fakeStat{idle: 1, acquired: 9, total: 10, max: 10, acquires: 100, emptyAcquires: 3, acquireDuration: time.Second}
+	assert.Equal(t, checkers.PoolStats{
+		Idle:    1,
+		InUse:   9,
+		Total:   10,
+		Max:     10,
+		Acquire: &checkers.AcquireStats{Waits: 3, WaitTime: time.Second, Acquires: 100},
+	}, poolStats(stat))
+
+	readiness := checkers.PoolReadinessProber(func() checkers.PoolStats { return poolStats(stat) }, nil,
+		checkers.WithPassiveReadiness(), checkers.WithMaxAcquireWait(10*time.Millisecond))
+
+	// NOTE: many fast acquires and one empty acquire during round.
+	stat.acquires += 10_000
+	stat.emptyAcquires++
+	stat.acquireDuration += 100 * time.Millisecond
+
+	res := readiness(context.Background())
+	assert.Equal(t, healing.UP, res.Status, res.Error)
+	assert.Equal(t, int64(1), res.Details["acquire_waits"])
+	assert.Equal(t, "10µs", res.Details["acquire_wait"])
+}
@@ -0,0 +1,61 @@
+package checkers
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+	"github.com/moeryomenko/healing"
+)
+
+// ErrUnexpectedResult indicates that result of query doesn't satisfy expectations.
+var ErrUnexpectedResult = errors.New("unexpected result of query")
+
+// SQLQueryProber returns checker function, which runs given query and checks
+// its result by given predicate, e.g. version of schema or existence of table.
+// Rows are closed after predicate returns.
+func SQLQueryProber(db *sql.DB, query string, predicate func(*sql.Rows) error, opts ...QueryOptions) healing.CheckerFunc {
+	return queryProber(func(ctx context.Context) error {
+		rows, err := db.QueryContext(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		if err := predicate(rows); err != nil {
+			return err
+		}
+		return rows.Err()
+	}, newQueryConfig(opts...))
+}
+
+// PgxQueryProber returns checker function, which runs given query by pgxpool.Pool,
+// for more details see SQLQueryProber.
+func PgxQueryProber(pool *pgxpool.Pool, query string, predicate func(pgx.Rows) error, opts ...QueryOptions) healing.CheckerFunc {
+	return queryProber(func(ctx context.Context) error {
+		rows, err := pool.Query(ctx, query)
+		if err != nil {
+			return err
+		}
+		defer rows.Close()
+
+		if err := predicate(rows); err != nil {
+			return err
+		}
+		return rows.Err()
+	}, newQueryConfig(opts...))
+}
+
+func queryProber(query func(context.Context) error, cfg query_config) healing.CheckerFunc {
+	return func(ctx context.Context) healing.CheckResult {
+		if cfg.timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, cfg.timeout)
+			defer cancel()
+		}
+
+		return CheckHelper(func() error { return query(ctx) })
+	}
+}
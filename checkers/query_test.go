@@ -0,0 +1,32 @@
+package checkers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/moeryomenko/healing"
+)
+
+func TestQueryProber(t *testing.T) {
+	// NOTE: query blocks until context is done.
+	slow := func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	failed := func(context.Context) error { return ErrUnexpectedResult }
+	ok := func(context.Context) error { return nil }
+
+	res := queryProber(slow, newQueryConfig(WithQueryTimeout(10*time.Millisecond)))(context.Background())
+	assert.Equal(t, healing.DOWN, res.Status)
+	assert.ErrorIs(t, res.Error, context.DeadlineExceeded)
+
+	res = queryProber(failed, newQueryConfig())(context.Background())
+	assert.Equal(t, healing.DOWN, res.Status)
+	assert.ErrorIs(t, res.Error, ErrUnexpectedResult)
+
+	res = queryProber(ok, newQueryConfig())(context.Background())
+	assert.Equal(t, healing.UP, res.Status)
+}
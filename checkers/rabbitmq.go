@@ -9,8 +9,11 @@ import (
 	"github.com/streadway/amqp"
 )
 
+// AQMPProber returns liveness and readiness probes for streadway amqp connection.
+//
+// Deprecated: streadway/amqp is archived, use AMQPProbes with rabbitmq/amqp091-go.
 func AQMPProber(conn *amqp.Connection, heartbeatPeriod time.Duration) (
-	liveness, readiness func(context.Context) healing.CheckResult,
+	liveness, readiness healing.CheckerFunc,
 ) {
 	blocked := make(chan amqp.Blocking, 0)
 	blocked = conn.NotifyBlocked(blocked)
@@ -8,16 +8,54 @@ import (
 	"github.com/moeryomenko/healing"
 )
 
-func RedigoReadinessProber(pool *redis.Pool, opts ...PoolOptions) func(context.Context) healing.CheckResult {
-	cfg := pool_config{lowerLimit: defaultLowerLimit}
+// RedigoProbes returns liveness and readiness probes for redigo redis.Pool.
+func RedigoProbes(pool *redis.Pool, opts ...PoolOptions) (
+	liveness, readiness healing.CheckerFunc,
+) {
+	return PoolLivenessProber(redigoPing(pool), opts...), RedigoReadinessProber(pool, opts...)
+}
+
+// RedigoReadinessProber returns redigo conn pool readiness checker function.
+func RedigoReadinessProber(pool *redis.Pool, opts ...PoolOptions) healing.CheckerFunc {
+	return PoolReadinessProber(func() PoolStats {
+		stats := pool.Stats()
+		return PoolStats{
+			Idle:    stats.IdleCount,
+			InUse:   max(stats.ActiveCount-stats.IdleCount, 0),
+			Total:   stats.ActiveCount,
+			Max:     pool.MaxActive,
+			Acquire: &AcquireStats{Waits: stats.WaitCount, WaitTime: stats.WaitDuration},
+		}
+	}, redigoPing(pool), opts...)
+}
 
-	for _, opt := range opts {
-		opt(&cfg)
+// RedigoNodeProber returns readiness checker function of redis node for redis.Pool,
+// for more details see RedisNodeProber.
+func RedigoNodeProber(pool *redis.Pool, opts ...RedisInfoOptions) healing.CheckerFunc {
+	cfg := newRedisInfoConfig(opts...)
+
+	return func(ctx context.Context) healing.CheckResult {
+		details := make(map[string]any)
+		res := CheckHelper(func() error {
+			var info string
+			err := redigoQuery(ctx, pool, func(conn redis.Conn) (err error) {
+				info, err = redis.String(redis.DoContext(conn, ctx, `INFO`))
+				return err
+			})
+			if err != nil {
+				return err
+			}
+			return redisInfoCheck(info, cfg, details)
+		})
+		res.Details = details
+		return res
 	}
+}
 
-	ping := func(ctx context.Context) error {
+func redigoPing(pool *redis.Pool) func(context.Context) error {
+	return func(ctx context.Context) error {
 		return redigoQuery(ctx, pool, func(conn redis.Conn) error {
-			pong, err := redis.String(conn.Do(`PING`))
+			pong, err := redis.String(redis.DoContext(conn, ctx, `PING`))
 			if err != nil {
 				return err
 			}
@@ -27,13 +65,6 @@ func RedigoReadinessProber(pool *redis.Pool, opts ...PoolOptions) func(context.C
 			return nil
 		})
 	}
-
-	return func(ctx context.Context) healing.CheckResult {
-		return CheckHelper(func() error {
-			stats := pool.Stats()
-			return poolCheck(ctx, stats.IdleCount, pool.MaxActive, cfg.lowerLimit, ping)
-		})
-	}
 }
 
 func redigoQuery(ctx context.Context, pool *redis.Pool, f func(redis.Conn) error) (err error) {
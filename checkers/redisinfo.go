@@ -0,0 +1,107 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+var (
+	// ErrRedisReplicationLink indicates that replica has lost link with master.
+	ErrRedisReplicationLink = errors.New("redis replication link is down")
+	// ErrRedisReplicationLag indicates that replicas fall too far behind master.
+	ErrRedisReplicationLag = errors.New("redis replication offset lag exceeds limit")
+	// ErrRedisPersistence indicates that redis failed to persist data.
+	ErrRedisPersistence = errors.New("redis persistence failed")
+)
+
+// redisInfoCheck checks redis node by raw reply of INFO command without arguments,
+// which includes replication and persistence sections, for more details see checkRedisInfo.
+func redisInfoCheck(info string, cfg redis_info_config, details map[string]any) error {
+	return checkRedisInfo(parseRedisInfo(info), cfg, details)
+}
+
+// parseRedisInfo parses output of INFO command to fields.
+func parseRedisInfo(info string) map[string]string {
+	fields := make(map[string]string)
+	for _, line := range strings.Split(info, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if key, value, ok := strings.Cut(line, ":"); ok {
+			fields[key] = value
+		}
+	}
+	return fields
+}
+
+// checkRedisInfo checks replication and persistence of redis node by fields of INFO
+// and fills details of check result.
+func checkRedisInfo(fields map[string]string, cfg redis_info_config, details map[string]any) error {
+	for _, key := range []string{"role", "master_link_status", "rdb_last_bgsave_status", "aof_last_write_status"} {
+		if value, ok := fields[key]; ok {
+			details[key] = value
+		}
+	}
+
+	var errs []error
+
+	if fields["role"] == "slave" && fields["master_link_status"] != "up" {
+		errs = append(errs, ErrRedisReplicationLink)
+	}
+
+	if lag, ok := redisOffsetLag(fields); ok {
+		details["replication_offset_lag"] = lag
+		if cfg.maxOffsetLag > 0 && lag > cfg.maxOffsetLag {
+			errs = append(errs, fmt.Errorf("%w: %d > %d", ErrRedisReplicationLag, lag, cfg.maxOffsetLag))
+		}
+	}
+
+	for _, key := range []string{"rdb_last_bgsave_status", "aof_last_write_status"} {
+		if fields[key] == "err" {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrRedisPersistence, key))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// redisOffsetLag returns max difference between replication offset of master and offsets
+// of its connected replicas, lag is known only on master with replicas.
+func redisOffsetLag(fields map[string]string) (int64, bool) {
+	if fields["role"] != "master" {
+		return 0, false
+	}
+	masterOffset, err := strconv.ParseInt(fields["master_repl_offset"], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	var (
+		lag   int64
+		known bool
+	)
+	for key, value := range fields {
+		// NOTE: replica is described as `slave0:ip=10.0.0.1,port=6379,state=online,offset=42,lag=0`.
+		if !strings.HasPrefix(key, "slave") {
+			continue
+		}
+		if _, err := strconv.Atoi(strings.TrimPrefix(key, "slave")); err != nil {
+			continue
+		}
+		for _, attr := range strings.Split(value, ",") {
+			offset, ok := strings.CutPrefix(attr, "offset=")
+			if !ok {
+				continue
+			}
+			replicaOffset, err := strconv.ParseInt(offset, 10, 64)
+			if err != nil {
+				continue
+			}
+			lag, known = max(lag, masterOffset-replicaOffset), true
+		}
+	}
+	return lag, known
+}
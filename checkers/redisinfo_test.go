@@ -0,0 +1,121 @@
+package checkers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRedisInfo(t *testing.T) {
+	info := "# Replication\r\nrole:master\r\nconnected_slaves:1\r\n" +
+		"slave0:ip=10.0.0.2,port=6379,state=online,offset=90,lag=0\r\n" +
+		"master_repl_offset:100\r\n\r\n# Persistence\r\nrdb_last_bgsave_status:ok\r\n"
+
+	assert.Equal(t, map[string]string{
+		"role":                   "master",
+		"connected_slaves":       "1",
+		"slave0":                 "ip=10.0.0.2,port=6379,state=online,offset=90,lag=0",
+		"master_repl_offset":     "100",
+		"rdb_last_bgsave_status": "ok",
+	}, parseRedisInfo(info))
+}
+
+func TestCheckRedisInfo(t *testing.T) {
+	testcases := []struct {
+		name    string
+		fields  map[string]string
+		opts    []RedisInfoOptions
+		details map[string]any
+		errs    []error
+	}{
+		{
+			name: "healthy master",
+			fields: map[string]string{
+				"role":                   "master",
+				"master_repl_offset":     "100",
+				"slave0":                 "ip=10.0.0.2,port=6379,state=online,offset=90,lag=0",
+				"slave1":                 "ip=10.0.0.3,port=6379,state=online,offset=95,lag=0",
+				"rdb_last_bgsave_status": "ok",
+				"aof_last_write_status":  "ok",
+			},
+			opts: []RedisInfoOptions{WithMaxReplicationOffsetLag(10)},
+			details: map[string]any{
+				"role":                   "master",
+				"rdb_last_bgsave_status": "ok",
+				"aof_last_write_status":  "ok",
+				"replication_offset_lag": int64(10),
+			},
+		},
+		{
+			name: "lagging replica",
+			fields: map[string]string{
+				"role":               "master",
+				"master_repl_offset": "100",
+				"slave0":             "ip=10.0.0.2,port=6379,state=online,offset=50,lag=1",
+			},
+			opts: []RedisInfoOptions{WithMaxReplicationOffsetLag(10)},
+			details: map[string]any{
+				"role":                   "master",
+				"replication_offset_lag": int64(50),
+			},
+			errs: []error{ErrRedisReplicationLag},
+		},
+		{
+			name: "replica lost master",
+			fields: map[string]string{
+				"role":                   "slave",
+				"master_link_status":     "down",
+				"rdb_last_bgsave_status": "err",
+				"aof_last_write_status":  "err",
+			},
+			details: map[string]any{
+				"role":                   "slave",
+				"master_link_status":     "down",
+				"rdb_last_bgsave_status": "err",
+				"aof_last_write_status":  "err",
+			},
+			errs: []error{ErrRedisReplicationLink, ErrRedisPersistence},
+		},
+		{
+			name: "healthy replica",
+			fields: map[string]string{
+				"role":               "slave",
+				"master_link_status": "up",
+			},
+			details: map[string]any{
+				"role":               "slave",
+				"master_link_status": "up",
+			},
+		},
+	}
+	for _, testcase := range testcases {
+		tc := testcase
+		t.Run(tc.name, func(t *testing.T) {
+			details := map[string]any{}
+			err := checkRedisInfo(tc.fields, newRedisInfoConfig(tc.opts...), details)
+			if len(tc.errs) == 0 {
+				assert.NoError(t, err)
+			}
+			for _, expected := range tc.errs {
+				assert.ErrorIs(t, err, expected)
+			}
+			assert.Equal(t, tc.details, details)
+		})
+	}
+}
+
+func TestRedisInfoCheck(t *testing.T) {
+	info := "# Server\r\nredis_version:6.2.14\r\n\r\n# Persistence\r\nrdb_last_bgsave_status:err\r\n" +
+		"aof_last_write_status:ok\r\n\r\n# Replication\r\nrole:slave\r\nmaster_link_status:down\r\n"
+
+	details := map[string]any{}
+	err := redisInfoCheck(info, newRedisInfoConfig(), details)
+	assert.ErrorIs(t, err, ErrRedisReplicationLink)
+	assert.ErrorIs(t, err, ErrRedisPersistence)
+	assert.Equal(t, map[string]any{
+		"role":                   "slave",
+		"master_link_status":     "down",
+		"rdb_last_bgsave_status": "err",
+		"aof_last_write_status":  "ok",
+	}, details)
+}
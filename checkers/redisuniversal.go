@@ -0,0 +1,183 @@
+package checkers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/moeryomenko/healing"
+	"github.com/redis/go-redis/v9"
+)
+
+var (
+	// ErrRedisShardsFailed indicates that some shards of redis cluster or ring don't respond.
+	ErrRedisShardsFailed = errors.New("redis shards failed")
+	// ErrRedisClusterState indicates that redis cluster isn't in ok state.
+	ErrRedisClusterState = errors.New("redis cluster state is not ok")
+	// ErrRedisNotMaster indicates that failover client isn't connected to master known by sentinel.
+	ErrRedisNotMaster = errors.New("redis node is not master")
+)
+
+// RedisUniversalProber returns readiness checker function for redis.UniversalClient.
+// Every master and replica of cluster client is pinged and checked for `cluster_state:ok`,
+// every shard of ring client is pinged, other clients are pinged. Failed shards are
+// reported in details of check result.
+func RedisUniversalProber(client redis.UniversalClient) healing.CheckerFunc {
+	return func(ctx context.Context) healing.CheckResult {
+		switch c := client.(type) {
+		case *redis.ClusterClient:
+			return shardsCheck(ctx, c.ForEachShard, clusterShardCheck)
+		case *redis.Ring:
+			return shardsCheck(ctx, c.ForEachShard, func(ctx context.Context, shard *redis.Client) error {
+				return shard.Ping(ctx).Err()
+			})
+		default:
+			return CheckHelper(func() error { return client.Ping(ctx).Err() })
+		}
+	}
+}
+
+// RedisFailoverProber returns readiness checker function for failover client, which verifies
+// that client is connected to master of given name known by sentinel: run ID of connected node
+// must be equal to run ID of master reported by sentinel, so stale master after failover
+// isn't considered ready. Address and run ID of master are reported in details of check result.
+func RedisFailoverProber(client redis.UniversalClient, sentinel *redis.SentinelClient, masterName string) healing.CheckerFunc {
+	return func(ctx context.Context) healing.CheckResult {
+		details := make(map[string]any)
+		res := CheckHelper(func() error {
+			master, err := sentinel.Master(ctx, masterName).Result()
+			if err != nil {
+				return fmt.Errorf("sentinel: %w", err)
+			}
+
+			info, err := client.Info(ctx, "server").Result()
+			if err != nil {
+				return err
+			}
+			return checkFailoverMaster(master, parseRedisInfo(info), details)
+		})
+		res.Details = details
+		return res
+	}
+}
+
+// checkFailoverMaster compares master reported by `SENTINEL MASTER` with node described
+// by `INFO server` and fills details of check result.
+func checkFailoverMaster(master, server map[string]string, details map[string]any) error {
+	if ip, port := master["ip"], master["port"]; ip != "" && port != "" {
+		details["master_addr"] = net.JoinHostPort(ip, port)
+	}
+	details["master_run_id"] = master["runid"]
+	details["run_id"] = server["run_id"]
+
+	for _, flag := range strings.Split(master["flags"], ",") {
+		// NOTE: master is subjectively or objectively down by sentinel.
+		if flag == "s_down" || flag == "o_down" {
+			return fmt.Errorf("%w: master is %s", ErrRedisNotMaster, master["flags"])
+		}
+	}
+
+	switch {
+	case master["runid"] == "":
+		return fmt.Errorf("%w: run id of master is unknown", ErrRedisNotMaster)
+	case master["runid"] != server["run_id"]:
+		return fmt.Errorf("%w: connected to %s, master is %s",
+			ErrRedisNotMaster, server["run_id"], master["runid"])
+	}
+	return nil
+}
+
+// shardsCheck checks every shard by given check and reports failed shards.
+func shardsCheck(
+	ctx context.Context,
+	forEach func(context.Context, func(context.Context, *redis.Client) error) error,
+	check func(context.Context, *redis.Client) error,
+) healing.CheckResult {
+	var failures shardFailures
+
+	// NOTE: errors of shards are collected, so all shards are checked.
+	err := forEach(ctx, func(ctx context.Context, shard *redis.Client) error {
+		if err := check(ctx, shard); err != nil {
+			failures.add(shard.Options().Addr, err)
+		}
+		return nil
+	})
+
+	res := CheckHelper(func() error { return errors.Join(err, failures.err()) })
+	res.Details = failures.details()
+	return res
+}
+
+func clusterShardCheck(ctx context.Context, shard *redis.Client) error {
+	if err := shard.Ping(ctx).Err(); err != nil {
+		return err
+	}
+
+	info, err := shard.ClusterInfo(ctx).Result()
+	if err != nil {
+		return err
+	}
+	return clusterState(info)
+}
+
+// clusterState checks state of cluster by output of CLUSTER INFO.
+func clusterState(info string) error {
+	for _, line := range strings.Split(info, "\n") {
+		state, ok := strings.CutPrefix(strings.TrimSpace(line), "cluster_state:")
+		if !ok {
+			continue
+		}
+		if state != "ok" {
+			return fmt.Errorf("%w: %s", ErrRedisClusterState, state)
+		}
+		return nil
+	}
+	return fmt.Errorf("%w: state is unknown", ErrRedisClusterState)
+}
+
+// shardFailures collects errors of shards checked concurrently.
+type shardFailures struct {
+	mu     sync.Mutex
+	errors map[string]error
+}
+
+func (f *shardFailures) add(addr string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if f.errors == nil {
+		f.errors = make(map[string]error)
+	}
+	f.errors[addr] = err
+}
+
+func (f *shardFailures) err() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if len(f.errors) == 0 {
+		return nil
+	}
+
+	addrs := make([]string, 0, len(f.errors))
+	for addr := range f.errors {
+		addrs = append(addrs, addr)
+	}
+	sort.Strings(addrs)
+	return fmt.Errorf("%w: %s", ErrRedisShardsFailed, strings.Join(addrs, ", "))
+}
+
+func (f *shardFailures) details() map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	failed := make(map[string]string, len(f.errors))
+	for addr, err := range f.errors {
+		failed[addr] = err.Error()
+	}
+	return map[string]any{"failed_shards": failed}
+}
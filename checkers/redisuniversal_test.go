@@ -0,0 +1,63 @@
+package checkers
+
+import (
+	"errors"
+	"maps"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClusterState(t *testing.T) {
+	assert.NoError(t, clusterState("cluster_enabled:1\r\ncluster_state:ok\r\ncluster_slots_assigned:16384\r\n"))
+	assert.ErrorIs(t, clusterState("cluster_state:fail\r\n"), ErrRedisClusterState)
+	assert.ErrorIs(t, clusterState(""), ErrRedisClusterState)
+}
+
+func TestShardFailures(t *testing.T) {
+	var failures shardFailures
+	assert.NoError(t, failures.err())
+	assert.Equal(t, map[string]any{"failed_shards": map[string]string{}}, failures.details())
+
+	failures.add("redis-2:6379", errors.New("timeout"))
+	failures.add("redis-1:6379", ErrRedisClusterState)
+
+	err := failures.err()
+	assert.ErrorIs(t, err, ErrRedisShardsFailed)
+	assert.EqualError(t, err, "redis shards failed: redis-1:6379, redis-2:6379")
+	assert.Equal(t, map[string]any{"failed_shards": map[string]string{
+		"redis-1:6379": "redis cluster state is not ok",
+		"redis-2:6379": "timeout",
+	}}, failures.details())
+}
+
+func TestCheckFailoverMaster(t *testing.T) {
+	master := map[string]string{
+		"name":  "mymaster",
+		"ip":    "10.0.0.1",
+		"port":  "6379",
+		"runid": "a1b2c3",
+		"flags": "master",
+	}
+
+	details := map[string]any{}
+	assert.NoError(t, checkFailoverMaster(master, map[string]string{"run_id": "a1b2c3"}, details))
+	assert.Equal(t, map[string]any{
+		"master_addr":   "10.0.0.1:6379",
+		"master_run_id": "a1b2c3",
+		"run_id":        "a1b2c3",
+	}, details)
+
+	// NOTE: old master still considers itself master after failover.
+	err := checkFailoverMaster(master, map[string]string{"run_id": "d4e5f6"}, map[string]any{})
+	assert.ErrorIs(t, err, ErrRedisNotMaster)
+	assert.ErrorContains(t, err, "connected to d4e5f6, master is a1b2c3")
+
+	down := maps.Clone(master)
+	down["flags"] = "master,s_down"
+	assert.ErrorIs(t, checkFailoverMaster(down, map[string]string{"run_id": "a1b2c3"}, map[string]any{}), ErrRedisNotMaster)
+
+	unknown := maps.Clone(master)
+	delete(unknown, "runid")
+	assert.ErrorIs(t, checkFailoverMaster(unknown, map[string]string{}, map[string]any{}), ErrRedisNotMaster)
+}
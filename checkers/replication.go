@@ -0,0 +1,56 @@
+package checkers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	// ErrReplicationLag indicates that replica falls too far behind primary.
+	ErrReplicationLag = errors.New("replication lag exceeds limit")
+	// ErrNotPrimary indicates that node expected to be primary is replica.
+	ErrNotPrimary = errors.New("node is not primary")
+	// ErrReplicationStopped indicates that replication threads of replica have stopped.
+	ErrReplicationStopped = errors.New("replication is stopped")
+)
+
+// replicationState is state of replication of database node.
+type replicationState struct {
+	replica bool
+	// lag is replication lag of replica, nil if it's unknown.
+	lag *time.Duration
+	// stopped is names of stopped replication threads.
+	stopped []string
+}
+
+// check checks replication state by given limits and fills details of check result.
+func (s replicationState) check(cfg replication_config, details map[string]any) error {
+	details["replica"] = s.replica
+	if s.lag != nil {
+		details["replication_lag"] = s.lag.String()
+	}
+	if len(s.stopped) > 0 {
+		details["stopped_threads"] = s.stopped
+	}
+
+	if !s.replica {
+		return nil
+	}
+
+	switch {
+	case cfg.expectPrimary:
+		return ErrNotPrimary
+	case len(s.stopped) > 0:
+		return fmt.Errorf("%w: %s", ErrReplicationStopped, strings.Join(s.stopped, ", "))
+	case cfg.maxLag <= 0:
+		return nil
+	case s.lag == nil:
+		// NOTE: replica, which hasn't replayed anything yet, can't be trusted.
+		return fmt.Errorf("%w: lag is unknown", ErrReplicationLag)
+	case *s.lag > cfg.maxLag:
+		return fmt.Errorf("%w: %s > %s", ErrReplicationLag, *s.lag, cfg.maxLag)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package checkers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplicationState(t *testing.T) {
+	lag := func(d time.Duration) *time.Duration { return &d }
+
+	testcases := []struct {
+		name  string
+		state replicationState
+		opts  []ReplicationOptions
+		err   error
+	}{
+		{
+			name:  "primary",
+			state: replicationState{},
+			opts:  []ReplicationOptions{WithExpectPrimary(), WithMaxReplicationLag(time.Second)},
+		},
+		{
+			name:  "replica instead of primary",
+			state: replicationState{replica: true, lag: lag(0)},
+			opts:  []ReplicationOptions{WithExpectPrimary()},
+			err:   ErrNotPrimary,
+		},
+		{
+			name:  "unlimited lag",
+			state: replicationState{replica: true, lag: lag(time.Hour)},
+		},
+		{
+			name:  "lag below limit",
+			state: replicationState{replica: true, lag: lag(time.Second)},
+			opts:  []ReplicationOptions{WithMaxReplicationLag(5 * time.Second)},
+		},
+		{
+			name:  "lag above limit",
+			state: replicationState{replica: true, lag: lag(10 * time.Second)},
+			opts:  []ReplicationOptions{WithMaxReplicationLag(5 * time.Second)},
+			err:   ErrReplicationLag,
+		},
+		{
+			name:  "unknown lag",
+			state: replicationState{replica: true},
+			opts:  []ReplicationOptions{WithMaxReplicationLag(5 * time.Second)},
+			err:   ErrReplicationLag,
+		},
+	}
+	for _, testcase := range testcases {
+		tc := testcase
+		t.Run(tc.name, func(t *testing.T) {
+			details := map[string]any{}
+			err := tc.state.check(newReplicationConfig(tc.opts...), details)
+			if tc.err == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tc.err)
+			}
+			assert.Equal(t, tc.state.replica, details["replica"])
+			if tc.state.lag != nil {
+				assert.Equal(t, tc.state.lag.String(), details["replication_lag"])
+			}
+		})
+	}
+}
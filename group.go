@@ -2,62 +2,224 @@ package healing
 
 import (
 	"context"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/moeryomenko/synx"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
-const defaultCheckTimeout = 2 * time.Second
+const (
+	defaultCheckTimeout = 2 * time.Second
 
-// CheckGroup launch checker concurrently.
+	tracerName = "github.com/moeryomenko/healing"
+)
+
+// CheckGroup launch checker concurrently, either round of all checks by Check
+// or every checker on its own schedule by Run.
+// Checkers can be added, replaced and removed at any time, including during checks.
 type CheckGroup struct {
-	checkers map[string]checkFunc
+	name     string
+	checkers map[string]*subsystem
 	timeout  time.Duration
-	status   atomic.Bool
+	status   atomic.Value // SubsystemStatus
+
+	// latch keeps group successful after all checks have passed once,
+	// requirePass keeps group failed until all checks have passed once.
+	latch       bool
+	requirePass bool
+	passed      atomic.Bool
+
+	metrics *Metrics
+	tracer  trace.Tracer
+
+	// scheduler state, runCtx is set during Run.
+	runCtx context.Context
+	period time.Duration
+	loops  sync.WaitGroup
+
+	mu synx.Spinlock
+}
+
+// GroupOption configures CheckGroup.
+type GroupOption func(*CheckGroup)
 
-	checkStatuses map[string]CheckResult
-	mu            synx.Spinlock
+// WithGroupName sets name of group, it's used as `group` label of metrics.
+func WithGroupName(name string) GroupOption {
+	return func(g *CheckGroup) {
+		g.name = name
+	}
+}
+
+// WithGroupMetrics sets metrics, which record result of every check.
+func WithGroupMetrics(metrics *Metrics) GroupOption {
+	return func(g *CheckGroup) {
+		g.metrics = metrics
+	}
+}
+
+// WithGroupTracerProvider sets provider of tracer, which traces every check round
+// with child span per subsystem. Span context is propagated to checkers.
+func WithGroupTracerProvider(provider trace.TracerProvider) GroupOption {
+	return func(g *CheckGroup) {
+		g.tracer = provider.Tracer(tracerName)
+	}
 }
 
 // NewCheckGroup returns new instacnce CheckGroup.
-func NewCheckGroup(timeout time.Duration) *CheckGroup {
+func NewCheckGroup(timeout time.Duration, opts ...GroupOption) *CheckGroup {
 	group := &CheckGroup{
-		timeout:       timeout,
-		checkers:      make(map[string]checkFunc),
-		checkStatuses: make(map[string]CheckResult),
+		timeout:  timeout,
+		checkers: make(map[string]*subsystem),
+		tracer:   noop.NewTracerProvider().Tracer(tracerName),
 	}
+
+	for _, opt := range opts {
+		opt(group)
+	}
+
 	return group
 }
 
-// AddChecker adds checker to CheckGroup.
-func (g *CheckGroup) AddChecker(subsystem string, checker checkFunc) {
-	g.checkers[subsystem] = checker
+// newStartupCheckGroup returns new instance CheckGroup, which latches to success
+// after all checks have passed once.
+func newStartupCheckGroup(timeout time.Duration, opts ...GroupOption) *CheckGroup {
+	group := NewCheckGroup(timeout, opts...)
+	group.latch = true
+	return group
+}
+
+// AddChecker adds checker function of given subsystem to CheckGroup.
+func (g *CheckGroup) AddChecker(subsystem string, checker CheckerFunc, opts ...CheckOption) {
+	g.Register(NewChecker(subsystem, checker), opts...)
+}
+
+// Register adds checker to CheckGroup.
+func (g *CheckGroup) Register(checker Checker, opts ...CheckOption) {
+	s := newSubsystem(checker, opts...)
+
+	g.mu.Lock()
+	if old, ok := g.checkers[s.name]; ok {
+		old.cancel()
+	}
+	g.checkers[s.name] = s
+	g.schedule(s, s.initialDelay)
+	g.mu.Unlock()
+}
+
+// ReplaceChecker replaces checker function of given subsystem.
+// For more details see Replace.
+func (g *CheckGroup) ReplaceChecker(subsystem string, checker CheckerFunc, opts ...CheckOption) {
+	g.Replace(NewChecker(subsystem, checker), opts...)
 }
 
-// Check runs checkers.
+// Replace replaces checker of subsystem keeping its current status and
+// consecutive checks counters, result of running check of replaced checker is discarded.
+// If subsystem isn't registered, checker is added to CheckGroup.
+func (g *CheckGroup) Replace(checker Checker, opts ...CheckOption) {
+	s := newSubsystem(checker, opts...)
+
+	g.mu.Lock()
+	if old, ok := g.checkers[s.name]; ok {
+		old.cancel()
+		s.inherit(old)
+	}
+	g.checkers[s.name] = s
+	g.schedule(s, s.initialDelay)
+	g.mu.Unlock()
+}
+
+// RemoveChecker removes checker of given subsystem from CheckGroup,
+// its result is removed from details too.
+func (g *CheckGroup) RemoveChecker(subsystem string) {
+	g.mu.Lock()
+	s, ok := g.checkers[subsystem]
+	if ok {
+		s.cancel()
+	}
+	delete(g.checkers, subsystem)
+	g.mu.Unlock()
+
+	if ok {
+		g.metrics.forget(g.name, subsystem)
+	}
+
+	// NOTE: removed subsystem could affect status of group, so recalculate it
+	// if checks have been launched already.
+	if _, launched := g.status.Load().(SubsystemStatus); ok && launched {
+		g.refresh()
+	}
+}
+
+// Check runs round of all checkers regardless of their schedules.
 func (g *CheckGroup) Check(ctx context.Context) {
-	ctx, cancel := context.WithTimeout(ctx, g.timeout)
-	defer cancel()
+	g.round(ctx, false)
+}
 
-	group := synx.NewCtxGroup(ctx)
+// Run runs every checker on its own schedule until context is done. Subsystem
+// without own interval is checked with given period. First scheduled check of subsystem
+// runs after its initial delay, or after interval if delay isn't set, since it's expected
+// that round of checks has been launched just before. Checkers added during Run are
+// checked immediately, unless they have initial delay.
+func (g *CheckGroup) Run(ctx context.Context, period time.Duration) {
+	g.mu.Lock()
+	g.runCtx, g.period = ctx, period
+	for _, s := range g.checkers {
+		delay := s.initialDelay
+		if delay <= 0 {
+			delay = s.period(period)
+		}
+		g.schedule(s, delay)
+	}
+	g.mu.Unlock()
 
-	// NOTE: flush status before checks.
-	g.status.Store(true)
+	<-ctx.Done()
 
-	for subsystem, checker := range g.checkers {
-		subsystem := subsystem
-		checker := checker
-		group.Go(func(ctx context.Context) error {
-			res := checker(ctx)
-			g.setStatus(subsystem, res)
-			return res.Error
-		})
+	g.mu.Lock()
+	g.runCtx = nil
+	g.mu.Unlock()
+
+	g.loops.Wait()
+}
+
+// round runs checkers concurrently and waits for them, in initial round
+// subsystems with initial delay are skipped.
+func (g *CheckGroup) round(ctx context.Context, initial bool) {
+	// NOTE: latched group doesn't need checks anymore.
+	if g.latch && g.passed.Load() {
+		return
 	}
 
-	err := group.Wait()
-	if err != nil {
-		g.status.Store(false)
+	ctx, span := g.tracer.Start(ctx, "healing.check",
+		trace.WithAttributes(attribute.String("healing.group", g.name)))
+	defer span.End()
+
+	var wg sync.WaitGroup
+
+	for _, s := range g.subsystems() {
+		if initial && s.initialDelay > 0 {
+			continue
+		}
+
+		wg.Add(1)
+		go func(s *subsystem) {
+			defer wg.Done()
+
+			g.setStatus(s, g.check(ctx, s))
+		}(s)
+	}
+
+	wg.Wait()
+
+	status := g.refresh()
+
+	span.SetAttributes(attribute.String("healing.status", string(status)))
+	if status == DOWN {
+		span.SetStatus(codes.Error, "group is down")
 	}
 }
 
@@ -65,16 +227,197 @@ func (g *CheckGroup) Check(ctx context.Context) {
 func (g *CheckGroup) GetDetails() map[string]CheckResult {
 	g.mu.Lock()
 	defer g.mu.Unlock()
-	return g.checkStatuses
+
+	details := make(map[string]CheckResult, len(g.checkers))
+	for name, s := range g.checkers {
+		if s.checked {
+			details[name] = s.result
+		}
+	}
+	return details
 }
 
-// IsOK returns true if all checks passed normal.
+// IsOK returns true if all critical subsystems are UP.
+// For latched group it returns true if all checks have passed at least once.
 func (g *CheckGroup) IsOK() bool {
-	return g.status.Load()
+	return g.Status() != DOWN
 }
 
-func (g *CheckGroup) setStatus(subsystem string, status CheckResult) {
+// Status returns aggregated status of group: DOWN if any critical subsystem is DOWN,
+// DEGRADED if any non-critical subsystem is DOWN, otherwise UP.
+func (g *CheckGroup) Status() SubsystemStatus {
+	passed := g.passed.Load()
+	if g.latch && passed {
+		return UP
+	}
+	if g.requirePass && !passed {
+		return DOWN
+	}
+
+	status, ok := g.status.Load().(SubsystemStatus)
+	if !ok {
+		// NOTE: checks have not been launched yet.
+		return DOWN
+	}
+	return status
+}
+
+// schedule launches periodic checks of subsystem if group is running,
+// first check runs after given delay.
+// NOTE: must be called under lock.
+func (g *CheckGroup) schedule(s *subsystem, delay time.Duration) {
+	if g.runCtx == nil {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(g.runCtx)
+	s.stop = cancel
+	period := g.period
+
+	g.loops.Add(1)
+	go func() {
+		defer g.loops.Done()
+		defer cancel()
+
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+			}
+
+			// NOTE: latched group doesn't need checks anymore.
+			if g.latch && g.passed.Load() {
+				return
+			}
+
+			g.setStatus(s, g.check(ctx, s))
+			g.refresh()
+
+			timer.Reset(s.period(period))
+		}
+	}()
+}
+
+// refresh recalculates status of group.
+func (g *CheckGroup) refresh() SubsystemStatus {
+	status, passed := g.aggregate()
+	g.status.Store(status)
+	if passed {
+		g.passed.Store(true)
+	}
+	return status
+}
+
+func (g *CheckGroup) subsystems() []*subsystem {
 	g.mu.Lock()
-	g.checkStatuses[subsystem] = status
+	defer g.mu.Unlock()
+
+	subsystems := make([]*subsystem, 0, len(g.checkers))
+	for _, s := range g.checkers {
+		subsystems = append(subsystems, s)
+	}
+	return subsystems
+}
+
+func (g *CheckGroup) setStatus(s *subsystem, res CheckResult) {
+	g.mu.Lock()
+	// NOTE: subsystem could be removed or replaced during check.
+	if g.checkers[s.name] != s {
+		g.mu.Unlock()
+		return
+	}
+	s.update(res)
+	status := s.status
 	g.mu.Unlock()
+
+	g.metrics.observe(g.name, s.name, status, res)
+}
+
+// aggregate returns status of group and reports whether last checks
+// of all critical subsystems have passed.
+func (g *CheckGroup) aggregate() (status SubsystemStatus, passed bool) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	status, passed = UP, true
+	for _, s := range g.checkers {
+		if s.critical && (!s.checked || s.failures > 0) {
+			passed = false
+		}
+		if s.status == UP {
+			continue
+		}
+		if s.critical {
+			return DOWN, false
+		}
+		status = DEGRADED
+	}
+	return status, passed
+}
+
+// check runs checker of subsystem with its timeout in own span.
+func (g *CheckGroup) check(ctx context.Context, s *subsystem) CheckResult {
+	ctx, span := g.tracer.Start(ctx, "healing.check.subsystem", trace.WithAttributes(
+		attribute.String("healing.group", g.name),
+		attribute.String("healing.subsystem", s.name),
+	))
+	defer span.End()
+
+	timeout := s.timeout
+	if timeout <= 0 {
+		timeout = g.timeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	res := runCheck(ctx, s.check)
+
+	status := UP
+	if res.failed() {
+		status = DOWN
+	}
+	span.SetAttributes(
+		attribute.String("healing.status", string(status)),
+		attribute.Int64("healing.duration_ms", res.Duration.Milliseconds()),
+	)
+	if res.Error != nil {
+		span.RecordError(res.Error)
+		span.SetStatus(codes.Error, res.Error.Error())
+	}
+
+	return res
+}
+
+// runCheck runs checker and guarantees return after context is done,
+// even if checker ignores context.
+func runCheck(ctx context.Context, checker Checker) (res CheckResult) {
+	start := time.Now()
+	defer func() {
+		res.Duration = time.Since(start)
+	}()
+
+	result := make(chan CheckResult, 1)
+
+	go func() {
+		var checked CheckResult
+		err := synx.Graceful(ctx, func(ctx context.Context) error {
+			checked = checker.Check(ctx)
+			return nil
+		})
+		if err != nil {
+			checked = CheckResult{Error: err, Status: DOWN}
+		}
+		result <- checked
+	}()
+
+	select {
+	case res = <-result:
+		return res
+	case <-ctx.Done():
+		return CheckResult{Error: ctx.Err(), Status: DOWN}
+	}
 }
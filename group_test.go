@@ -2,25 +2,32 @@ package healing
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 func TestCheckGroup_Check(t *testing.T) {
 	testcases := []struct {
 		name          string
-		checkers      []checkFunc
+		checkers      []CheckerFunc
 		checkTimeout  time.Duration
 		checkDuration time.Duration
 		expected      bool
 	}{
 		{
 			name: "basic case",
-			checkers: []checkFunc{
+			checkers: []CheckerFunc{
 				func(ctx context.Context) CheckResult {
 					return CheckResult{}
 				},
@@ -31,7 +38,7 @@ func TestCheckGroup_Check(t *testing.T) {
 		},
 		{
 			name: "long check",
-			checkers: []checkFunc{
+			checkers: []CheckerFunc{
 				func(ctx context.Context) CheckResult {
 					<-time.After(300 * time.Millisecond)
 					return CheckResult{}
@@ -43,7 +50,7 @@ func TestCheckGroup_Check(t *testing.T) {
 		},
 		{
 			name: "failed check",
-			checkers: []checkFunc{
+			checkers: []CheckerFunc{
 				func(ctx context.Context) CheckResult {
 					return CheckResult{Error: errors.New("failed check")}
 				},
@@ -74,3 +81,271 @@ func TestCheckGroup_Check(t *testing.T) {
 		})
 	}
 }
+
+func TestCheckGroup_Latch(t *testing.T) {
+	var calls int
+	g := newStartupCheckGroup(100 * time.Millisecond)
+	g.AddChecker("subsystem", func(ctx context.Context) CheckResult {
+		calls++
+		if calls < 2 {
+			return CheckResult{Error: errors.New("not started yet")}
+		}
+		return CheckResult{}
+	})
+
+	g.Check(context.Background())
+	assert.False(t, g.IsOK())
+
+	g.Check(context.Background())
+	assert.True(t, g.IsOK())
+
+	// after success checks are not launched anymore.
+	g.Check(context.Background())
+	assert.True(t, g.IsOK())
+	assert.Equal(t, 2, calls)
+}
+
+func TestCheckGroup_Thresholds(t *testing.T) {
+	testcases := []struct {
+		name     string
+		opts     []CheckOption
+		results  []error
+		expected []bool
+	}{
+		{
+			name:     "default thresholds",
+			results:  []error{nil, errors.New("failed"), nil},
+			expected: []bool{true, false, true},
+		},
+		{
+			name:     "failure threshold",
+			opts:     []CheckOption{WithFailureThreshold(2)},
+			results:  []error{nil, errors.New("failed"), nil, errors.New("failed"), errors.New("failed")},
+			expected: []bool{true, true, true, true, false},
+		},
+		{
+			name:     "success threshold",
+			opts:     []CheckOption{WithSuccessThreshold(2)},
+			results:  []error{nil, nil, errors.New("failed"), nil, nil},
+			expected: []bool{false, true, false, false, true},
+		},
+		{
+			name:     "initial status",
+			opts:     []CheckOption{WithInitialStatus(UP), WithFailureThreshold(3)},
+			results:  []error{errors.New("failed"), errors.New("failed"), errors.New("failed")},
+			expected: []bool{true, true, false},
+		},
+	}
+	for _, testcase := range testcases {
+		tc := testcase
+		t.Run(tc.name, func(t *testing.T) {
+			round := 0
+			g := NewCheckGroup(100 * time.Millisecond)
+			g.AddChecker("subsystem", func(ctx context.Context) CheckResult {
+				return CheckResult{Error: tc.results[round]}
+			}, tc.opts...)
+			for ; round < len(tc.results); round++ {
+				g.Check(context.Background())
+				assert.Equal(t, tc.expected[round], g.IsOK(), "round %d", round)
+			}
+		})
+	}
+}
+
+func TestCheckGroup_NonCritical(t *testing.T) {
+	g := NewCheckGroup(100 * time.Millisecond)
+	g.AddChecker("database", func(ctx context.Context) CheckResult {
+		return CheckResult{}
+	})
+	g.AddChecker("cache", func(ctx context.Context) CheckResult {
+		return CheckResult{Error: errors.New("failed check")}
+	}, NonCritical())
+
+	g.Check(context.Background())
+	assert.True(t, g.IsOK())
+	assert.Equal(t, DEGRADED, g.Status())
+	assert.Equal(t, DEGRADED, g.GetDetails()["cache"].Status)
+
+	g.AddChecker("queue", func(ctx context.Context) CheckResult {
+		return CheckResult{Error: errors.New("failed check")}
+	})
+
+	g.Check(context.Background())
+	assert.False(t, g.IsOK())
+	assert.Equal(t, DOWN, g.Status())
+}
+
+type stubChecker struct {
+	name string
+	err  error
+}
+
+func (c stubChecker) Name() string { return c.name }
+
+func (c stubChecker) Check(context.Context) CheckResult { return CheckResult{Error: c.err} }
+
+func TestCheckGroup_Register(t *testing.T) {
+	g := NewCheckGroup(100 * time.Millisecond)
+	g.Register(stubChecker{name: "stub"})
+	g.Register(NewChecker("func", func(ctx context.Context) CheckResult {
+		return CheckResult{Error: errors.New("failed check")}
+	}))
+
+	g.Check(context.Background())
+	assert.False(t, g.IsOK())
+
+	details := g.GetDetails()
+	assert.Equal(t, UP, details["stub"].Status)
+	assert.Equal(t, DOWN, details["func"].Status)
+}
+
+func TestCheckGroup_Dynamic(t *testing.T) {
+	g := NewCheckGroup(100 * time.Millisecond)
+	g.AddChecker("tenant", func(ctx context.Context) CheckResult {
+		return CheckResult{Error: errors.New("failed check")}
+	})
+
+	g.Check(context.Background())
+	assert.False(t, g.IsOK())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for ctx.Err() == nil {
+			g.Check(ctx)
+		}
+	}()
+
+	for i := 0; i < 100; i++ {
+		subsystem := fmt.Sprintf("tenant%d", i)
+		g.AddChecker(subsystem, func(ctx context.Context) CheckResult {
+			return CheckResult{}
+		})
+		g.ReplaceChecker(subsystem, func(ctx context.Context) CheckResult {
+			return CheckResult{}
+		})
+		g.RemoveChecker(subsystem)
+	}
+	cancel()
+	<-done
+
+	g.RemoveChecker("tenant")
+	assert.True(t, g.IsOK())
+	assert.NotContains(t, g.GetDetails(), "tenant")
+
+	g.AddChecker("tenant", func(ctx context.Context) CheckResult {
+		return CheckResult{}
+	})
+	g.Check(context.Background())
+	g.ReplaceChecker("tenant", func(ctx context.Context) CheckResult {
+		return CheckResult{Error: errors.New("failed check")}
+	}, WithFailureThreshold(2))
+	g.Check(context.Background())
+	assert.True(t, g.IsOK(), "replaced checker keeps status")
+}
+
+func TestCheckGroup_Details(t *testing.T) {
+	fail := false
+	g := NewCheckGroup(100 * time.Millisecond)
+	g.AddChecker("pool", func(ctx context.Context) CheckResult {
+		if fail {
+			return CheckResult{Error: errors.New("pool is busy"), Details: map[string]any{"idle_conns": 0}}
+		}
+		return CheckResult{Details: map[string]any{"idle_conns": 1}}
+	})
+
+	g.Check(context.Background())
+	res := g.GetDetails()["pool"]
+	assert.False(t, res.LastChecked.IsZero())
+	assert.Equal(t, res.LastChecked, res.LastSuccess)
+
+	fail = true
+	g.Check(context.Background())
+	g.Check(context.Background())
+	res = g.GetDetails()["pool"]
+	assert.Equal(t, 2, res.ConsecutiveFailures)
+	assert.True(t, res.LastChecked.After(res.LastSuccess))
+	assert.Equal(t, map[string]any{"idle_conns": 0}, res.Details)
+
+	body, err := json.Marshal(res)
+	assert.NoError(t, err)
+
+	var decoded map[string]any
+	assert.NoError(t, json.Unmarshal(body, &decoded))
+	assert.Equal(t, "pool is busy", decoded["error"])
+	assert.Equal(t, "DOWN", decoded["status"])
+	assert.Contains(t, decoded, "duration")
+	assert.Contains(t, decoded, "last_checked")
+	assert.Contains(t, decoded, "last_success")
+	assert.EqualValues(t, 2, decoded["consecutive_failures"])
+}
+
+func TestCheckGroup_Tracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	var parent trace.SpanContext
+	g := NewCheckGroup(100*time.Millisecond, WithGroupName("ready"), WithGroupTracerProvider(provider))
+	g.AddChecker("database", func(ctx context.Context) CheckResult {
+		parent = trace.SpanContextFromContext(ctx)
+		return CheckResult{Error: errors.New("failed check")}
+	})
+
+	g.Check(context.Background())
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+
+	subsystem, round := spans[0], spans[1]
+	assert.Equal(t, "healing.check", round.Name())
+	assert.Equal(t, "healing.check.subsystem", subsystem.Name())
+	assert.Equal(t, round.SpanContext().SpanID(), subsystem.Parent().SpanID())
+	assert.Equal(t, subsystem.SpanContext().SpanID(), parent.SpanID())
+	assert.Contains(t, subsystem.Attributes(), attribute.String("healing.subsystem", "database"))
+	assert.Contains(t, subsystem.Attributes(), attribute.String("healing.status", "DOWN"))
+	assert.Equal(t, codes.Error, subsystem.Status().Code)
+}
+
+func TestCheckGroup_Run(t *testing.T) {
+	var fast, slow, delayed, added atomic.Int32
+	counter := func(calls *atomic.Int32) CheckerFunc {
+		return func(ctx context.Context) CheckResult {
+			calls.Add(1)
+			return CheckResult{}
+		}
+	}
+
+	g := NewCheckGroup(100 * time.Millisecond)
+	g.AddChecker("fast", counter(&fast), WithCheckInterval(10*time.Millisecond), WithJitter(time.Millisecond))
+	g.AddChecker("slow", counter(&slow))
+	g.AddChecker("delayed", counter(&delayed), WithInitialDelay(50*time.Millisecond), WithCheckInterval(time.Hour))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		g.Run(ctx, time.Hour)
+	}()
+
+	// NOTE: wait for scheduler is running.
+	assert.Eventually(t, func() bool { return fast.Load() >= 1 }, time.Second, 5*time.Millisecond)
+
+	g.AddChecker("added", counter(&added), WithCheckInterval(time.Hour))
+	assert.Eventually(t, func() bool { return added.Load() == 1 }, time.Second, 5*time.Millisecond)
+
+	assert.Eventually(t, func() bool { return delayed.Load() == 1 }, time.Second, 5*time.Millisecond)
+	assert.Eventually(t, func() bool { return fast.Load() >= 5 }, time.Second, 5*time.Millisecond)
+
+	g.RemoveChecker("fast")
+	calls := fast.Load()
+	<-time.After(50 * time.Millisecond)
+	assert.LessOrEqual(t, fast.Load(), calls+1)
+
+	cancel()
+	<-done
+
+	assert.Zero(t, slow.Load())
+	assert.Equal(t, int32(1), added.Load())
+	assert.Equal(t, int32(1), delayed.Load())
+}
@@ -11,13 +11,24 @@ import (
 	"sync"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	defaultCheckPeriod   = 3 * time.Second
 	defaultHealzEndpoint = "/live"
 	defaultReadyEndpoint = "/ready"
+	defaultStartEndpoint = "/startup"
+
+	// StatusHeader is header of probe response, which contains aggregated status of group:
+	// UP, DEGRADED or DOWN.
+	StatusHeader = "X-Health-Status"
+
+	livenessGroup  = "live"
+	readinessGroup = "ready"
+	startupGroup   = "startup"
 
 	// It's default health and ready timeout.
 	// see: https://github.com/kubernetes/kubernetes/blob/3b13e9445a3bf86c94781c898f224e6690399178/pkg/apis/core/v1/defaults.go#L211
@@ -27,38 +38,119 @@ const (
 type SubsystemStatus string
 
 const (
-	UP   SubsystemStatus = "UP"
-	DOWN SubsystemStatus = "DOWN"
+	UP       SubsystemStatus = "UP"
+	DOWN     SubsystemStatus = "DOWN"
+	DEGRADED SubsystemStatus = "DEGRADED"
 )
 
+// Checker checks state of subsystem.
+type Checker interface {
+	// Name returns name of checked subsystem.
+	Name() string
+	// Check returns result of subsystem check.
+	Check(context.Context) CheckResult
+}
+
+// CheckerFunc is an adapter to allow the use of ordinary functions as checkers.
 // The checkers must be compatible with this type.
-type checkFunc func(context.Context) CheckResult
+type CheckerFunc func(context.Context) CheckResult
+
+// Check calls f(ctx).
+func (f CheckerFunc) Check(ctx context.Context) CheckResult {
+	return f(ctx)
+}
+
+// NewChecker returns Checker of given subsystem.
+func NewChecker(subsystem string, check CheckerFunc) Checker {
+	return namedChecker{name: subsystem, CheckerFunc: check}
+}
+
+type namedChecker struct {
+	CheckerFunc
+	name string
+}
+
+func (c namedChecker) Name() string {
+	return c.name
+}
 
 type CheckResult struct {
-	Error  error           `json:"error,omitempty"`
-	Status SubsystemStatus `json:"status"`
+	Error  error
+	Status SubsystemStatus
+	// Duration is time spent on check.
+	Duration time.Duration
+	// LastChecked and LastSuccess are times of last check and last successful check.
+	LastChecked time.Time
+	LastSuccess time.Time
+	// ConsecutiveFailures is number of consecutive failed checks.
+	ConsecutiveFailures int
+	// Details contains arbitrary subsystem specific data, e.g. pool statistics.
+	Details map[string]any
+}
+
+// failed returns true if check has failed.
+func (r CheckResult) failed() bool {
+	return r.Error != nil || r.Status == DOWN
+}
+
+// MarshalJSON implements json.Marshaler, error is encoded as its message.
+func (r CheckResult) MarshalJSON() ([]byte, error) {
+	result := struct {
+		Error               string          `json:"error,omitempty"`
+		Status              SubsystemStatus `json:"status"`
+		Duration            string          `json:"duration,omitempty"`
+		LastChecked         *time.Time      `json:"last_checked,omitempty"`
+		LastSuccess         *time.Time      `json:"last_success,omitempty"`
+		ConsecutiveFailures int             `json:"consecutive_failures,omitempty"`
+		Details             map[string]any  `json:"details,omitempty"`
+	}{
+		Status:              r.Status,
+		ConsecutiveFailures: r.ConsecutiveFailures,
+		Details:             r.Details,
+	}
+
+	if r.Error != nil {
+		result.Error = r.Error.Error()
+	}
+	if r.Duration > 0 {
+		result.Duration = r.Duration.String()
+	}
+	if !r.LastChecked.IsZero() {
+		result.LastChecked = &r.LastChecked
+	}
+	if !r.LastSuccess.IsZero() {
+		result.LastSuccess = &r.LastSuccess
+	}
+
+	return json.Marshal(result)
 }
 
 type Health struct {
 	liveness       *CheckGroup
 	readiness      *CheckGroup
+	startup        *CheckGroup
 	server         *http.Server
 	router         *http.ServeMux
 	requestTimeout time.Duration
 	checkPeriod    time.Duration
+	registerer     prometheus.Registerer
+	tracerProvider trace.TracerProvider
 
-	healz, ready string
+	healz, ready, start string
 
 	wg sync.WaitGroup
 }
 
 func New(port int, opts ...Option) *Health {
 	h := &Health{
-		liveness:       NewCheckGroup(defaultCheckTimeout),
-		readiness:      NewCheckGroup(defaultCheckTimeout),
+		liveness:       NewCheckGroup(defaultCheckTimeout, WithGroupName(livenessGroup)),
+		readiness:      NewCheckGroup(defaultCheckTimeout, WithGroupName(readinessGroup)),
+		startup:        newStartupCheckGroup(defaultCheckTimeout, WithGroupName(startupGroup)),
 		checkPeriod:    defaultCheckPeriod,
+		registerer:     prometheus.DefaultRegisterer,
 		healz:          defaultHealzEndpoint,
 		ready:          defaultReadyEndpoint,
+		start:          defaultStartEndpoint,
 		requestTimeout: defaultRequestTimeout,
 		router:         http.NewServeMux(),
 	}
@@ -67,27 +159,47 @@ func New(port int, opts ...Option) *Health {
 		opt(h)
 	}
 
+	if h.registerer != nil {
+		metrics := NewMetrics(h.registerer)
+		for _, group := range h.groups() {
+			group.metrics = metrics
+		}
+	}
+
+	if h.tracerProvider != nil {
+		for _, group := range h.groups() {
+			WithGroupTracerProvider(h.tracerProvider)(group)
+		}
+	}
+
 	handler := func(checker *CheckGroup) http.HandlerFunc {
 		return http.TimeoutHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			if !checker.IsOK() {
-				w.WriteHeader(http.StatusServiceUnavailable)
-			}
+			// NOTE: aggregated status is reported by header, so body keeps details of checks only.
+			status := checker.Status()
+			w.Header().Set(StatusHeader, string(status))
 
 			// if it's a check from kubernetes dont write the details of checks, because k8s doesnt use them.
 			// see: https://github.com/kubernetes/kubernetes/blob/1df526b3f79a212f575889dc388158f48e9ac204/pkg/probe/http/http.go#L129-L136
-			if strings.HasPrefix(r.Header.Get("User-Agent"), "kube-probe") {
+			kubeProbe := strings.HasPrefix(r.Header.Get("User-Agent"), "kube-probe")
+			if !kubeProbe {
+				w.Header().Add("Content-Type", "application/json")
+			}
+
+			if status == DOWN {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+			if kubeProbe {
 				return
 			}
 
-			w.Header().Add("Content-Type", "application/json")
-			details := checker.GetDetails()
-			body, _ := json.Marshal(details)
+			body, _ := json.Marshal(checker.GetDetails())
 			w.Write(body)
 		}), h.requestTimeout, `timeout`).ServeHTTP
 	}
 
 	h.router.HandleFunc(h.healz, handler(h.liveness))
 	h.router.HandleFunc(h.ready, handler(h.readiness))
+	h.router.HandleFunc(h.start, handler(h.startup))
 
 	h.server = &http.Server{
 		Addr:    fmt.Sprintf(":%d", port),
@@ -99,7 +211,8 @@ func New(port int, opts ...Option) *Health {
 
 type Option func(*Health)
 
-// WithCheckPeriod sets period of launch checks.
+// WithCheckPeriod sets period of launch checks,
+// it's used for checkers without own interval.
 func WithCheckPeriod(period time.Duration) Option {
 	return func(h *Health) {
 		h.checkPeriod = period
@@ -120,17 +233,31 @@ func WithReadyEndpoint(endpoint string) Option {
 	}
 }
 
+// WithStartupEndpoint sets custom endpoint to probe startup.
+func WithStartupEndpoint(endpoint string) Option {
+	return func(h *Health) {
+		h.start = endpoint
+	}
+}
+
 // WithLivenessTimeout sets custom timeout for check liveness.
 func WithLivenessTimeout(timeout time.Duration) Option {
 	return func(h *Health) {
-		h.liveness = NewCheckGroup(timeout)
+		h.liveness.timeout = timeout
 	}
 }
 
 // WithReadinessTimeout sets custom timeout for check readiness.
 func WithReadinessTimeout(timeout time.Duration) Option {
 	return func(h *Health) {
-		h.readiness = NewCheckGroup(timeout)
+		h.readiness.timeout = timeout
+	}
+}
+
+// WithStartupTimeout sets custom timeout for check startup.
+func WithStartupTimeout(timeout time.Duration) Option {
+	return func(h *Health) {
+		h.startup.timeout = timeout
 	}
 }
 
@@ -149,6 +276,31 @@ func WithMetrics(endpoint string) Option {
 	}
 }
 
+// WithMetricsRegisterer sets registerer of check metrics, by default
+// metrics are registered in prometheus.DefaultRegisterer. Nil registerer disables metrics.
+// NOTE: WithMetrics exposes only metrics of prometheus.DefaultGatherer.
+func WithMetricsRegisterer(reg prometheus.Registerer) Option {
+	return func(h *Health) {
+		h.registerer = reg
+	}
+}
+
+// WithTracerProvider sets provider of tracer, which traces every check round.
+// By default checks aren't traced.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(h *Health) {
+		h.tracerProvider = provider
+	}
+}
+
+// WithRequireInitialPass makes readiness probe fail until all readiness checks
+// have passed at least once, regardless of initial status of subsystems.
+func WithRequireInitialPass() Option {
+	return func(h *Health) {
+		h.readiness.requirePass = true
+	}
+}
+
 // WithProfiling exposes pprof handlers.
 func WithPProf() Option {
 	return func(h *Health) {
@@ -168,29 +320,104 @@ func WithPProf() Option {
 // AddLiveChecker adds a check routine for `live` state of your service to the registry.
 // Service health check only applies to internal components, whose state identifies the service liveness.
 // see https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle/#when-should-you-use-a-liveness-probe.
-func (h *Health) AddLiveChecker(subsystem string, check checkFunc) {
-	h.liveness.AddChecker(subsystem, check)
+func (h *Health) AddLiveChecker(subsystem string, check CheckerFunc, opts ...CheckOption) {
+	h.liveness.AddChecker(subsystem, check, opts...)
 }
 
 // AddReadyChecker adds a check routine for `ready` state of your service to the registry.
 // Service readiness check only applies to external dependencies, whose state identifies
 // the service readiness to accept load.
 // see https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle/#when-should-you-use-a-readiness-probe.
-func (h *Health) AddReadyChecker(subsystem string, check checkFunc) {
-	h.readiness.AddChecker(subsystem, check)
+func (h *Health) AddReadyChecker(subsystem string, check CheckerFunc, opts ...CheckOption) {
+	h.readiness.AddChecker(subsystem, check, opts...)
 }
 
-// AddSubsystem adds liveness and readiness checks for given subsystem.
+// RegisterLiveChecker adds checker for `live` state of your service to the registry.
+// For more details see AddLiveChecker.
+func (h *Health) RegisterLiveChecker(checker Checker, opts ...CheckOption) {
+	h.liveness.Register(checker, opts...)
+}
+
+// RegisterReadyChecker adds checker for `ready` state of your service to the registry.
+// For more details see AddReadyChecker.
+func (h *Health) RegisterReadyChecker(checker Checker, opts ...CheckOption) {
+	h.readiness.Register(checker, opts...)
+}
+
+// RegisterStartupChecker adds checker for `startup` state of your service to the registry.
+// For more details see AddStartupChecker.
+func (h *Health) RegisterStartupChecker(checker Checker, opts ...CheckOption) {
+	h.startup.Register(checker, opts...)
+}
+
+// AddStartupChecker adds a check routine for `startup` state of your service to the registry.
+// Startup probe fails until all startup checks have passed once, after that it always succeeds,
+// so slow initialization (migrations, cache warmup, etc.) doesn't get killed by liveness probe.
+// see https://kubernetes.io/docs/concepts/workloads/pods/pod-lifecycle/#when-should-you-use-a-startup-probe.
+func (h *Health) AddStartupChecker(subsystem string, check CheckerFunc, opts ...CheckOption) {
+	h.startup.AddChecker(subsystem, check, opts...)
+}
+
+// AddSubsystem adds liveness and readiness checks for given subsystem,
+// options are applied to both checks.
 // For more details see AddLiveChecker and AddReadChecker.
-func (h *Health) AddSubsystem(subsystem string, liveness, readiness checkFunc) {
-	h.AddLiveChecker(subsystem, liveness)
-	h.AddReadyChecker(subsystem, readiness)
+func (h *Health) AddSubsystem(subsystem string, liveness, readiness CheckerFunc, opts ...CheckOption) {
+	h.AddLiveChecker(subsystem, liveness, opts...)
+	h.AddReadyChecker(subsystem, readiness, opts...)
+}
+
+// ReplaceLiveChecker replaces check routine of subsystem for `live` state of your service.
+// For more details see CheckGroup.Replace.
+func (h *Health) ReplaceLiveChecker(subsystem string, check CheckerFunc, opts ...CheckOption) {
+	h.liveness.ReplaceChecker(subsystem, check, opts...)
+}
+
+// ReplaceReadyChecker replaces check routine of subsystem for `ready` state of your service.
+// For more details see CheckGroup.Replace.
+func (h *Health) ReplaceReadyChecker(subsystem string, check CheckerFunc, opts ...CheckOption) {
+	h.readiness.ReplaceChecker(subsystem, check, opts...)
+}
+
+// ReplaceStartupChecker replaces check routine of subsystem for `startup` state of your service.
+// For more details see CheckGroup.Replace.
+func (h *Health) ReplaceStartupChecker(subsystem string, check CheckerFunc, opts ...CheckOption) {
+	h.startup.ReplaceChecker(subsystem, check, opts...)
 }
 
-// Heartbeat periodically run all checkers for both `live` and `ready` states.
+// ReplaceSubsystem replaces liveness and readiness checks for given subsystem.
+// For more details see ReplaceLiveChecker and ReplaceReadyChecker.
+func (h *Health) ReplaceSubsystem(subsystem string, liveness, readiness CheckerFunc, opts ...CheckOption) {
+	h.ReplaceLiveChecker(subsystem, liveness, opts...)
+	h.ReplaceReadyChecker(subsystem, readiness, opts...)
+}
+
+// RemoveLiveChecker removes check routine of subsystem for `live` state of your service.
+func (h *Health) RemoveLiveChecker(subsystem string) {
+	h.liveness.RemoveChecker(subsystem)
+}
+
+// RemoveReadyChecker removes check routine of subsystem for `ready` state of your service.
+func (h *Health) RemoveReadyChecker(subsystem string) {
+	h.readiness.RemoveChecker(subsystem)
+}
+
+// RemoveStartupChecker removes check routine of subsystem for `startup` state of your service.
+func (h *Health) RemoveStartupChecker(subsystem string) {
+	h.startup.RemoveChecker(subsystem)
+}
+
+// RemoveSubsystem removes all check routines of given subsystem.
+func (h *Health) RemoveSubsystem(subsystem string) {
+	h.liveness.RemoveChecker(subsystem)
+	h.readiness.RemoveChecker(subsystem)
+	h.startup.RemoveChecker(subsystem)
+}
+
+// Heartbeat periodically run all checkers for `startup`, `live` and `ready` states,
+// every checker runs on its own schedule (see WithCheckInterval), by default with check period.
+// First round of checks runs synchronously before serving of probes.
 func (h *Health) Heartbeat(ctx context.Context) error {
-	checkTicker := time.NewTicker(h.checkPeriod)
-	defer checkTicker.Stop()
+	h.check(ctx)
 
 	errCh := make(chan error, 1)
 	defer close(errCh)
@@ -207,15 +434,20 @@ func (h *Health) Heartbeat(ctx context.Context) error {
 		errCh <- err
 	}()
 
-	for {
-		select {
-		case <-checkTicker.C:
-			h.runChecks(ctx, h.liveness.Check)
-			h.runChecks(ctx, h.readiness.Check)
-		case <-ctx.Done():
-			return <-errCh
-		}
+	var schedulers sync.WaitGroup
+	for _, group := range h.groups() {
+		schedulers.Add(1)
+		go func(group *CheckGroup) {
+			defer schedulers.Done()
+
+			group.Run(ctx, h.checkPeriod)
+		}(group)
 	}
+
+	<-ctx.Done()
+	schedulers.Wait()
+
+	return <-errCh
 }
 
 // Stop shutdowns health controller http server and health controller.
@@ -228,13 +460,24 @@ func (h *Health) Stop(ctx context.Context) error {
 	return err
 }
 
-func (h *Health) runChecks(ctx context.Context, checks func(context.Context)) {
-	h.wg.Add(1)
-	go func() {
-		defer h.wg.Done()
+// check runs initial round of checks for `startup`, `live` and `ready` states and waits for them.
+func (h *Health) check(ctx context.Context) {
+	var wg sync.WaitGroup
 
-		checks(ctx)
-	}()
+	for _, group := range h.groups() {
+		wg.Add(1)
+		go func(group *CheckGroup) {
+			defer wg.Done()
+
+			group.round(ctx, true)
+		}(group)
+	}
+
+	wg.Wait()
+}
+
+func (h *Health) groups() []*CheckGroup {
+	return []*CheckGroup{h.startup, h.liveness, h.readiness}
 }
 
 // Ported from Goji's middleware, source:
@@ -0,0 +1,106 @@
+package healing
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHealth_InitialCheck(t *testing.T) {
+	h := New(0, WithCheckPeriod(time.Hour), WithMetricsRegisterer(nil))
+	h.AddReadyChecker("database", func(ctx context.Context) CheckResult {
+		return CheckResult{}
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- h.Heartbeat(ctx) }()
+
+	assert.Eventually(t, func() bool {
+		resp := httptest.NewRecorder()
+		h.router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, defaultReadyEndpoint, nil))
+		return resp.Code == http.StatusOK
+	}, 500*time.Millisecond, 10*time.Millisecond)
+
+	require.NoError(t, h.Stop(context.Background()))
+	cancel()
+	require.NoError(t, <-errCh)
+}
+
+func TestHealth_RequireInitialPass(t *testing.T) {
+	fail := true
+	h := New(0, WithRequireInitialPass(), WithMetricsRegisterer(nil))
+	h.AddReadyChecker("database", func(ctx context.Context) CheckResult {
+		if fail {
+			return CheckResult{Error: errors.New("failed check")}
+		}
+		return CheckResult{}
+	}, WithInitialStatus(UP), WithFailureThreshold(3))
+
+	h.check(context.Background())
+	assert.False(t, h.readiness.IsOK())
+
+	fail = false
+	h.check(context.Background())
+	assert.True(t, h.readiness.IsOK())
+
+	fail = true
+	h.check(context.Background())
+	assert.True(t, h.readiness.IsOK())
+}
+
+func TestHealth_StartupChecker(t *testing.T) {
+	h := New(0, WithMetricsRegisterer(nil))
+	h.AddStartupChecker("migrations", func(ctx context.Context) CheckResult {
+		return CheckResult{Error: errors.New("failed check")}
+	})
+	h.AddStartupChecker("cache", func(ctx context.Context) CheckResult {
+		return CheckResult{Error: errors.New("failed check")}
+	})
+	h.check(context.Background())
+	assert.False(t, h.startup.IsOK())
+
+	h.ReplaceStartupChecker("migrations", func(ctx context.Context) CheckResult {
+		return CheckResult{}
+	})
+	h.RemoveStartupChecker("cache")
+	h.check(context.Background())
+	assert.True(t, h.startup.IsOK())
+	assert.NotContains(t, h.startup.GetDetails(), "cache")
+	assert.Equal(t, UP, h.startup.GetDetails()["migrations"].Status)
+}
+
+func TestHealth_Response(t *testing.T) {
+	h := New(0, WithMetricsRegisterer(nil))
+	h.AddReadyChecker("database", func(ctx context.Context) CheckResult {
+		return CheckResult{}
+	})
+	h.AddReadyChecker("cache", func(ctx context.Context) CheckResult {
+		return CheckResult{Error: errors.New("failed check")}
+	}, NonCritical())
+	h.check(context.Background())
+
+	resp := httptest.NewRecorder()
+	h.router.ServeHTTP(resp, httptest.NewRequest(http.MethodGet, defaultReadyEndpoint, nil))
+
+	assert.Equal(t, http.StatusOK, resp.Code)
+	assert.Equal(t, string(DEGRADED), resp.Header().Get(StatusHeader))
+	assert.Equal(t, "application/json", resp.Header().Get("Content-Type"))
+
+	// NOTE: body is map of results of subsystems.
+	var body map[string]map[string]any
+	require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &body))
+	assert.Equal(t, string(UP), body["database"]["status"])
+	// NOTE: failed non-critical subsystem is distinguishable from failed critical one.
+	assert.Equal(t, string(DEGRADED), body["cache"]["status"])
+	assert.Equal(t, "failed check", body["cache"]["error"])
+}
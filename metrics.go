@@ -0,0 +1,99 @@
+package healing
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "healing"
+
+// Metrics is a collection of prometheus metrics of checks,
+// labelled by group and subsystem.
+type Metrics struct {
+	status   *prometheus.GaugeVec
+	duration *prometheus.HistogramVec
+	failures *prometheus.CounterVec
+	timeouts *prometheus.CounterVec
+}
+
+// NewMetrics creates metrics of checks and registers them by given registerer.
+// Already registered metrics are reused, so several health controllers can share one registry.
+// It panics if metrics can't be registered, like prometheus.MustRegister.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	labels := []string{"group", "subsystem"}
+
+	return &Metrics{
+		status: register(reg, prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "check_status",
+			Help:      "Status of subsystem: 1 if subsystem is UP, 0 otherwise.",
+		}, labels)),
+		duration: register(reg, prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "check_duration_seconds",
+			Help:      "Duration of subsystem checks.",
+			Buckets:   prometheus.DefBuckets,
+		}, labels)),
+		failures: register(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "check_failures_total",
+			Help:      "Number of failed subsystem checks.",
+		}, labels)),
+		timeouts: register(reg, prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "check_timeouts_total",
+			Help:      "Number of timed out subsystem checks.",
+		}, labels)),
+	}
+}
+
+// observe records result of subsystem check.
+func (m *Metrics) observe(group, subsystem string, status SubsystemStatus, res CheckResult) {
+	if m == nil {
+		return
+	}
+
+	up := 0.
+	if status == UP {
+		up = 1
+	}
+	m.status.WithLabelValues(group, subsystem).Set(up)
+	m.duration.WithLabelValues(group, subsystem).Observe(res.Duration.Seconds())
+
+	if res.failed() {
+		m.failures.WithLabelValues(group, subsystem).Inc()
+	}
+	if errors.Is(res.Error, context.DeadlineExceeded) {
+		m.timeouts.WithLabelValues(group, subsystem).Inc()
+	}
+}
+
+// forget removes metrics of removed subsystem.
+func (m *Metrics) forget(group, subsystem string) {
+	if m == nil {
+		return
+	}
+
+	m.status.DeleteLabelValues(group, subsystem)
+	m.duration.DeleteLabelValues(group, subsystem)
+	m.failures.DeleteLabelValues(group, subsystem)
+	m.timeouts.DeleteLabelValues(group, subsystem)
+}
+
+func register[T prometheus.Collector](reg prometheus.Registerer, collector T) T {
+	err := reg.Register(collector)
+	if err == nil {
+		return collector
+	}
+
+	var registered prometheus.AlreadyRegisteredError
+	if errors.As(err, &registered) {
+		if existing, ok := registered.ExistingCollector.(T); ok {
+			return existing
+		}
+	}
+
+	panic(err)
+}
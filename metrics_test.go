@@ -0,0 +1,44 @@
+package healing
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	reg := prometheus.NewRegistry()
+	metrics := NewMetrics(reg)
+	// NOTE: metrics registered twice are reused.
+	assert.Equal(t, metrics, NewMetrics(reg))
+
+	g := NewCheckGroup(50*time.Millisecond, WithGroupName("ready"), WithGroupMetrics(metrics))
+	g.AddChecker("database", func(ctx context.Context) CheckResult {
+		return CheckResult{}
+	})
+	g.AddChecker("cache", func(ctx context.Context) CheckResult {
+		return CheckResult{Error: errors.New("failed check")}
+	})
+	g.AddChecker("queue", func(ctx context.Context) CheckResult {
+		<-ctx.Done()
+		return CheckResult{Error: ctx.Err()}
+	})
+
+	g.Check(context.Background())
+
+	assert.Equal(t, 1., testutil.ToFloat64(metrics.status.WithLabelValues("ready", "database")))
+	assert.Equal(t, 0., testutil.ToFloat64(metrics.status.WithLabelValues("ready", "cache")))
+	assert.Equal(t, 1., testutil.ToFloat64(metrics.failures.WithLabelValues("ready", "cache")))
+	assert.Equal(t, 1., testutil.ToFloat64(metrics.failures.WithLabelValues("ready", "queue")))
+	assert.Equal(t, 1., testutil.ToFloat64(metrics.timeouts.WithLabelValues("ready", "queue")))
+	assert.Equal(t, 0., testutil.ToFloat64(metrics.timeouts.WithLabelValues("ready", "cache")))
+	assert.Equal(t, 3, testutil.CollectAndCount(metrics.duration))
+
+	g.RemoveChecker("database")
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.duration))
+}
@@ -0,0 +1,182 @@
+package healing
+
+import (
+	"context"
+	"math/rand"
+	"time"
+)
+
+const (
+	defaultFailureThreshold = 1
+	defaultSuccessThreshold = 1
+)
+
+// CheckOption configures checker of subsystem.
+type CheckOption func(*subsystem)
+
+// WithFailureThreshold sets number of consecutive failures
+// after which subsystem is considered DOWN.
+func WithFailureThreshold(threshold uint) CheckOption {
+	return func(s *subsystem) {
+		s.failureThreshold = max(int(threshold), 1)
+	}
+}
+
+// WithSuccessThreshold sets number of consecutive successes
+// after which subsystem is considered UP.
+func WithSuccessThreshold(threshold uint) CheckOption {
+	return func(s *subsystem) {
+		s.successThreshold = max(int(threshold), 1)
+	}
+}
+
+// WithInitialStatus sets status of subsystem until thresholds are reached.
+func WithInitialStatus(status SubsystemStatus) CheckOption {
+	return func(s *subsystem) {
+		s.status = status
+	}
+}
+
+// NonCritical marks subsystem as non-critical, failure of such subsystem
+// makes group DEGRADED instead of DOWN.
+func NonCritical() CheckOption {
+	return func(s *subsystem) {
+		s.critical = false
+	}
+}
+
+// WithCheckInterval sets own period of launch checks of subsystem,
+// by default period of group is used.
+func WithCheckInterval(interval time.Duration) CheckOption {
+	return func(s *subsystem) {
+		s.interval = interval
+	}
+}
+
+// WithCheckTimeout sets own timeout of subsystem check,
+// by default timeout of group is used.
+func WithCheckTimeout(timeout time.Duration) CheckOption {
+	return func(s *subsystem) {
+		s.timeout = timeout
+	}
+}
+
+// WithInitialDelay sets delay before first scheduled check of subsystem.
+// Subsystem with initial delay is skipped in initial round of checks.
+func WithInitialDelay(delay time.Duration) CheckOption {
+	return func(s *subsystem) {
+		s.initialDelay = delay
+	}
+}
+
+// WithJitter sets upper bound of random delay added to every interval between checks,
+// it spreads checks of different instances of service in time.
+func WithJitter(jitter time.Duration) CheckOption {
+	return func(s *subsystem) {
+		s.jitter = jitter
+	}
+}
+
+// subsystem holds checker and state of its consecutive checks.
+type subsystem struct {
+	name     string
+	check    Checker
+	critical bool
+
+	interval     time.Duration
+	timeout      time.Duration
+	initialDelay time.Duration
+	jitter       time.Duration
+	// stop cancels scheduled checks of subsystem.
+	stop context.CancelFunc
+
+	failureThreshold int
+	successThreshold int
+
+	status    SubsystemStatus
+	failures  int
+	successes int
+
+	checked bool
+	result  CheckResult
+}
+
+func newSubsystem(check Checker, opts ...CheckOption) *subsystem {
+	s := &subsystem{
+		name:             check.Name(),
+		check:            check,
+		critical:         true,
+		failureThreshold: defaultFailureThreshold,
+		successThreshold: defaultSuccessThreshold,
+		status:           DOWN,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// cancel stops scheduled checks of subsystem.
+func (s *subsystem) cancel() {
+	if s.stop != nil {
+		s.stop()
+	}
+}
+
+// period returns interval of checks with jitter, default is used if subsystem hasn't own interval.
+func (s *subsystem) period(defaultInterval time.Duration) time.Duration {
+	interval := s.interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	return interval + s.randomJitter()
+}
+
+func (s *subsystem) randomJitter() time.Duration {
+	if s.jitter <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(s.jitter)))
+}
+
+// inherit takes over state of replaced subsystem.
+func (s *subsystem) inherit(old *subsystem) {
+	s.status = old.status
+	s.failures = old.failures
+	s.successes = old.successes
+	s.checked = old.checked
+	s.result = old.result
+}
+
+// update applies result of check to state of subsystem, status of subsystem
+// flips only after reaching of failure or success threshold. Result of non-critical
+// subsystem, which is DOWN, is reported as DEGRADED.
+func (s *subsystem) update(res CheckResult) {
+	res.LastChecked = time.Now()
+	res.LastSuccess = s.result.LastSuccess
+
+	if res.failed() {
+		s.successes = 0
+		s.failures++
+		if s.failures >= s.failureThreshold {
+			s.status = DOWN
+		}
+	} else {
+		res.LastSuccess = res.LastChecked
+		s.failures = 0
+		s.successes++
+		if s.successes >= s.successThreshold {
+			s.status = UP
+		}
+	}
+
+	res.Status = s.status
+	if !s.critical && s.status == DOWN {
+		res.Status = DEGRADED
+	}
+	res.ConsecutiveFailures = s.failures
+	s.result = res
+	s.checked = true
+}